@@ -0,0 +1,153 @@
+// Copyright (c) 2025 TalentEditor
+//
+// TalentEditor is licensed under the MIT License.
+// See the LICENSE file for details.
+
+package main
+
+import (
+    "fmt"
+    "sort"
+    "strconv"
+    "strings"
+
+    "fyne.io/fyne/v2"
+    "fyne.io/fyne/v2/container"
+    "fyne.io/fyne/v2/dialog"
+    "fyne.io/fyne/v2/widget"
+)
+
+// Known bits of Talent.Flags
+var talentFlagNames = map[int]string{
+    0: "Add to spellbook",
+}
+
+// BitNamer names a bit of a 32 bit field, empty for unknown bits
+type BitNamer func(bit int) string
+
+func talentFlagBitName(bit int) string {
+    return tr(talentFlagNames[bit])
+}
+
+// petFlagBitNamer names the bits of AllowForPetFlags1 (offset 0) or AllowForPetFlags2
+// (offset 32). Each bit of the 64 bit mask is a CreatureFamily category_enum_id.
+// Without CreatureFamily data all bits are unnamed.
+func petFlagBitNamer(ctx *AppContext, offset int) BitNamer {
+    families, err := GetAllCreatureFamilies(ctx)
+    if err != nil {
+        return func(int) string { return "" }
+    }
+
+    names := make(map[int][]string)
+    for _, f := range families {
+        if f.CategoryEnumID >= offset && f.CategoryEnumID < offset+32 && f.NameENUS != "" {
+            names[f.CategoryEnumID-offset] = append(names[f.CategoryEnumID-offset], f.NameENUS)
+        }
+    }
+    for bit := range names {
+        sort.Strings(names[bit])
+    }
+    return func(bit int) string {
+        return strings.Join(names[bit], "/")
+    }
+}
+
+// bitLabel is the checkbox label of a bit
+func bitLabel(bit int, name BitNamer) string {
+    if n := name(bit); n != "" {
+        return fmt.Sprintf("%d: %s", bit, n)
+    }
+    return trf("Bit %d", bit)
+}
+
+// decodeBits describes a 32 bit value as hex followed by the names of the set bits
+func decodeBits(value int64, name BitNamer) string {
+    v := uint32(value)
+    var set []string
+    for bit := 0; bit < 32; bit++ {
+        if v&(1<<bit) != 0 {
+            set = append(set, bitLabel(bit, name))
+        }
+    }
+    if len(set) == 0 {
+        return fmt.Sprintf("0x%08X %s", v, tr("(none)"))
+    }
+    return fmt.Sprintf("0x%08X %s", v, strings.Join(set, ", "))
+}
+
+// newBitfieldView shows the hex value and decoded bits of an integer entry and
+// offers a checkbox grid to edit it. The entry stays the source of the value.
+func newBitfieldView(ctx *AppContext, title string, entry *widget.Entry, name BitNamer) fyne.CanvasObject {
+    decoded := widget.NewLabel("")
+    decoded.Wrapping = fyne.TextWrapWord
+    decoded.TextStyle = fyne.TextStyle{Monospace: true}
+
+    update := func(text string) {
+        value, err := strconv.ParseInt(strings.TrimSpace(text), 10, 64)
+        if err != nil {
+            decoded.SetText(tr("invalid number"))
+            return
+        }
+        decoded.SetText(decodeBits(value, name))
+    }
+    prev := entry.OnChanged
+    entry.OnChanged = func(text string) {
+        if prev != nil {
+            prev(text)
+        }
+        update(text)
+    }
+    update(entry.Text)
+
+    editBtn := widget.NewButton(tr("Bits..."), func() {
+        value, _ := strconv.ParseInt(strings.TrimSpace(entry.Text), 10, 64)
+        showBitfieldDialog(ctx, title, uint32(value), name, func(v uint32) {
+            entry.SetText(strconv.FormatUint(uint64(v), 10))
+        })
+    })
+    if entry.Disabled() {
+        editBtn.Disable()
+    }
+
+    return container.NewBorder(nil, nil, nil, editBtn, decoded)
+}
+
+// showBitfieldDialog edits a 32 bit value as a grid of checkboxes
+func showBitfieldDialog(ctx *AppContext, title string, value uint32, name BitNamer, onSave func(uint32)) {
+    checks := make([]*widget.Check, 32)
+    grid := container.NewGridWithColumns(2)
+    hex := widget.NewLabel("")
+    hex.TextStyle = fyne.TextStyle{Monospace: true}
+
+    current := func() uint32 {
+        var v uint32
+        for bit, c := range checks {
+            if c.Checked {
+                v |= 1 << bit
+            }
+        }
+        return v
+    }
+    for bit := 0; bit < 32; bit++ {
+        checks[bit] = widget.NewCheck(bitLabel(bit, name), nil)
+        checks[bit].SetChecked(value&(1<<bit) != 0)
+        grid.Add(checks[bit])
+    }
+    for _, c := range checks {
+        c.OnChanged = func(bool) {
+            v := current()
+            hex.SetText(fmt.Sprintf("0x%08X = %d", v, v))
+        }
+    }
+    hex.SetText(fmt.Sprintf("0x%08X = %d", value, value))
+
+    scroll := container.NewVScroll(grid)
+    scroll.SetMinSize(fyne.NewSize(450, 400))
+    content := container.NewBorder(hex, nil, nil, nil, scroll)
+
+    dialog.ShowCustomConfirm(title, tr("OK"), tr("Cancel"), content, func(ok bool) {
+        if ok {
+            onSave(current())
+        }
+    }, ctx.Window)
+}
@@ -0,0 +1,292 @@
+// Copyright (c) 2025 TalentEditor
+//
+// TalentEditor is licensed under the MIT License.
+// See the LICENSE file for details.
+
+package main
+
+import (
+    "encoding/binary"
+    "errors"
+    "fmt"
+    "image"
+    "image/color"
+)
+
+// BLP2 compression types
+const (
+    blpCompressionPalette = 1
+    blpCompressionDXT     = 2
+    blpCompressionBGRA    = 3
+)
+
+// BLP2 alpha types of DXT compressed textures
+const (
+    blpAlphaTypeDXT3 = 1
+    blpAlphaTypeDXT5 = 7
+)
+
+const (
+    blp1HeaderSize = 156
+    blp2HeaderSize = 148
+    blpPaletteSize = 256 * 4
+    blpMaxSize     = 4096 // largest width or height the client loads
+)
+
+// decodeBLP decodes the first mipmap of a BLP1 or BLP2 texture as used by the 3.3.5 client.
+// Palettized, DXT1/3/5 and raw BGRA textures are supported, JPEG compressed BLP1 is not.
+func decodeBLP(data []byte) (*image.NRGBA, error) {
+    if len(data) < 4 {
+        return nil, errors.New("not a BLP file")
+    }
+    switch string(data[:4]) {
+    case "BLP1":
+        return decodeBLP1(data)
+    case "BLP2":
+        return decodeBLP2(data)
+    }
+    return nil, fmt.Errorf("not a BLP file, magic %q", data[:4])
+}
+
+func decodeBLP1(data []byte) (*image.NRGBA, error) {
+    if len(data) < blp1HeaderSize {
+        return nil, errors.New("BLP1 header is truncated")
+    }
+    le := binary.LittleEndian
+    compression := le.Uint32(data[4:])
+    alphaBits := int(le.Uint32(data[8:]))
+    offset, size := le.Uint32(data[28:]), le.Uint32(data[92:])
+    width, height, err := blpSize(data)
+    if err != nil {
+        return nil, err
+    }
+
+    if compression == 0 {
+        return nil, errors.New("JPEG compressed BLP1 textures are not supported")
+    }
+    if compression != 1 {
+        return nil, fmt.Errorf("unknown BLP1 compression %d", compression)
+    }
+    if len(data) < blp1HeaderSize+blpPaletteSize {
+        return nil, errors.New("BLP1 palette is truncated")
+    }
+    mip, err := blpMipmap(data, offset, size)
+    if err != nil {
+        return nil, err
+    }
+    return decodeBLPPalette(mip, data[blp1HeaderSize:blp1HeaderSize+blpPaletteSize], width, height, alphaBits)
+}
+
+func decodeBLP2(data []byte) (*image.NRGBA, error) {
+    if len(data) < blp2HeaderSize+blpPaletteSize {
+        return nil, errors.New("BLP2 header is truncated")
+    }
+    le := binary.LittleEndian
+    compression, alphaDepth, alphaType := data[8], int(data[9]), data[10]
+    offset, size := le.Uint32(data[20:]), le.Uint32(data[84:])
+    width, height, err := blpSize(data)
+    if err != nil {
+        return nil, err
+    }
+
+    mip, err := blpMipmap(data, offset, size)
+    if err != nil {
+        return nil, err
+    }
+    switch compression {
+    case blpCompressionPalette:
+        return decodeBLPPalette(mip, data[blp2HeaderSize:blp2HeaderSize+blpPaletteSize], width, height, alphaDepth)
+    case blpCompressionDXT:
+        switch {
+        case alphaDepth == 0:
+            return decodeDXT(mip, width, height, dxt1)
+        case alphaDepth == 1:
+            return decodeDXT(mip, width, height, dxt1a)
+        case alphaType == blpAlphaTypeDXT5:
+            return decodeDXT(mip, width, height, dxt5)
+        default:
+            return decodeDXT(mip, width, height, dxt3)
+        }
+    case blpCompressionBGRA:
+        if len(mip) < width*height*4 {
+            return nil, errors.New("BLP2 pixel data is truncated")
+        }
+        img := image.NewNRGBA(image.Rect(0, 0, width, height))
+        for i := 0; i < width*height; i++ {
+            b, g, r, a := mip[i*4], mip[i*4+1], mip[i*4+2], mip[i*4+3]
+            copy(img.Pix[i*4:], []byte{r, g, b, a})
+        }
+        return img, nil
+    }
+    return nil, fmt.Errorf("unknown BLP2 compression %d", compression)
+}
+
+// blpSize returns the texture size from the header, rejecting sizes the client would not
+// load before they are used for allocations
+func blpSize(data []byte) (int, int, error) {
+    width, height := binary.LittleEndian.Uint32(data[12:]), binary.LittleEndian.Uint32(data[16:])
+    if width == 0 || height == 0 || width > blpMaxSize || height > blpMaxSize {
+        return 0, 0, fmt.Errorf("invalid BLP size %dx%d", width, height)
+    }
+    return int(width), int(height), nil
+}
+
+// blpMipmap returns the data of a mipmap from its header offset and size
+func blpMipmap(data []byte, offset, size uint32) ([]byte, error) {
+    end := uint64(offset) + uint64(size)
+    if offset == 0 || size == 0 || end > uint64(len(data)) {
+        return nil, errors.New("BLP mipmap is outside the file")
+    }
+    return data[offset:end], nil
+}
+
+// decodeBLPPalette decodes one index byte per pixel into the BGRA palette, followed by
+// the alpha channel with 0, 1, 4 or 8 bits per pixel
+func decodeBLPPalette(mip, palette []byte, width, height, alphaBits int) (*image.NRGBA, error) {
+    pixels := width * height
+    if len(mip) < pixels+(pixels*alphaBits+7)/8 {
+        return nil, errors.New("BLP palette data is truncated")
+    }
+    alphas := mip[pixels:]
+
+    img := image.NewNRGBA(image.Rect(0, 0, width, height))
+    for i := 0; i < pixels; i++ {
+        entry := palette[int(mip[i])*4:]
+        a := byte(255)
+        switch alphaBits {
+        case 1:
+            if alphas[i/8]&(1<<(i%8)) == 0 {
+                a = 0
+            }
+        case 4:
+            a = (alphas[i/2] >> (4 * (i % 2)) & 0x0F) * 17
+        case 8:
+            a = alphas[i]
+        }
+        copy(img.Pix[i*4:], []byte{entry[2], entry[1], entry[0], a})
+    }
+    return img, nil
+}
+
+// dxtFormat is a DXT block compression
+type dxtFormat int
+
+const (
+    dxt1  dxtFormat = iota // opaque, the transparent color of 3-color blocks is black
+    dxt1a                  // 1-bit alpha from the transparent color of 3-color blocks
+    dxt3                   // explicit 4-bit alpha
+    dxt5                   // interpolated alpha
+)
+
+// dxt3Alpha reads the explicit 4-bit alpha of a DXT3 block
+func dxt3Alpha(block []byte, alpha *[16]byte) {
+    for i := range alpha {
+        alpha[i] = (block[i/2] >> (4 * (i % 2)) & 0x0F) * 17
+    }
+}
+
+// dxt5Alpha interpolates the alpha of a DXT5 block from two endpoints and 3-bit indices
+func dxt5Alpha(block []byte, alpha *[16]byte) {
+    a0, a1 := int(block[0]), int(block[1])
+    var table [8]int
+    table[0], table[1] = a0, a1
+    if a0 > a1 {
+        for i := 1; i < 7; i++ {
+            table[i+1] = ((7-i)*a0 + i*a1) / 7
+        }
+    } else {
+        for i := 1; i < 5; i++ {
+            table[i+1] = ((5-i)*a0 + i*a1) / 5
+        }
+        table[6], table[7] = 0, 255
+    }
+
+    var bits uint64
+    for i := 0; i < 6; i++ {
+        bits |= uint64(block[2+i]) << (8 * i)
+    }
+    for i := range alpha {
+        alpha[i] = byte(table[bits>>(3*i)&7])
+    }
+}
+
+// decodeDXT decodes DXT1/3/5 blocks of 4x4 pixels. DXT3 and DXT5 blocks start with
+// 8 bytes of alpha, the last 8 bytes of every block hold the colors.
+func decodeDXT(mip []byte, width, height int, format dxtFormat) (*image.NRGBA, error) {
+    blockSize := 16
+    if format == dxt1 || format == dxt1a {
+        blockSize = 8
+    }
+    blocksX, blocksY := (width+3)/4, (height+3)/4
+    if len(mip) < blocksX*blocksY*blockSize {
+        return nil, errors.New("DXT data is truncated")
+    }
+
+    img := image.NewNRGBA(image.Rect(0, 0, width, height))
+    for by := 0; by < blocksY; by++ {
+        for bx := 0; bx < blocksX; bx++ {
+            block := mip[(by*blocksX+bx)*blockSize:][:blockSize]
+            colorBlock := block[blockSize-8:]
+            colors := dxtColors(colorBlock, blockSize == 8)
+            if format == dxt1 {
+                colors[3].A = 255
+            }
+
+            var alphas [16]byte
+            switch format {
+            case dxt3:
+                dxt3Alpha(block, &alphas)
+            case dxt5:
+                dxt5Alpha(block, &alphas)
+            }
+
+            indices := binary.LittleEndian.Uint32(colorBlock[4:])
+            for i := 0; i < 16; i++ {
+                x, y := bx*4+i%4, by*4+i/4
+                if x >= width || y >= height {
+                    continue
+                }
+                c := colors[indices>>(2*i)&3]
+                if blockSize == 16 {
+                    c.A = alphas[i]
+                }
+                img.SetNRGBA(x, y, c)
+            }
+        }
+    }
+    return img, nil
+}
+
+// dxtColors returns the 4 colors of a DXT color block. DXT1 blocks whose first endpoint
+// is not greater than the second have 3 colors and a transparent one.
+func dxtColors(block []byte, dxt1 bool) [4]color.NRGBA {
+    c0, c1 := binary.LittleEndian.Uint16(block), binary.LittleEndian.Uint16(block[2:])
+    var colors [4]color.NRGBA
+    colors[0], colors[1] = rgb565(c0), rgb565(c1)
+    mix := func(a, b, wa, wb int) uint8 { return uint8((a*wa + b*wb) / (wa + wb)) }
+    lerp := func(wa, wb int) color.NRGBA {
+        return color.NRGBA{
+            R: mix(int(colors[0].R), int(colors[1].R), wa, wb),
+            G: mix(int(colors[0].G), int(colors[1].G), wa, wb),
+            B: mix(int(colors[0].B), int(colors[1].B), wa, wb),
+            A: 255,
+        }
+    }
+    if c0 > c1 || !dxt1 {
+        colors[2], colors[3] = lerp(2, 1), lerp(1, 2)
+    } else {
+        colors[2], colors[3] = lerp(1, 1), color.NRGBA{}
+    }
+    return colors
+}
+
+// rgb565 expands a 16 bit color to 8 bits per channel
+func rgb565(c uint16) color.NRGBA {
+    r, g, b := c>>11&0x1F, c>>5&0x3F, c&0x1F
+    return color.NRGBA{
+        R: uint8(r<<3 | r>>2),
+        G: uint8(g<<2 | g>>4),
+        B: uint8(b<<3 | b>>2),
+        A: 255,
+    }
+}
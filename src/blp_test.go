@@ -0,0 +1,134 @@
+// Copyright (c) 2025 TalentEditor
+//
+// TalentEditor is licensed under the MIT License.
+// See the LICENSE file for details.
+
+package main
+
+import (
+    "encoding/binary"
+    "image/color"
+    "testing"
+)
+
+// testBLP1 builds a BLP1 texture with one mipmap after the palette
+func testBLP1(compression, alphaBits, width, height uint32, palette, mip []byte) []byte {
+    data := make([]byte, blp1HeaderSize+blpPaletteSize)
+    le := binary.LittleEndian
+    copy(data, "BLP1")
+    le.PutUint32(data[4:], compression)
+    le.PutUint32(data[8:], alphaBits)
+    le.PutUint32(data[12:], width)
+    le.PutUint32(data[16:], height)
+    le.PutUint32(data[28:], uint32(len(data)))
+    le.PutUint32(data[92:], uint32(len(mip)))
+    copy(data[blp1HeaderSize:], palette)
+    return append(data, mip...)
+}
+
+// testBLP2 builds a BLP2 texture with one mipmap after the palette
+func testBLP2(compression, alphaDepth, alphaType byte, width, height uint32, palette, mip []byte) []byte {
+    data := make([]byte, blp2HeaderSize+blpPaletteSize)
+    le := binary.LittleEndian
+    copy(data, "BLP2")
+    le.PutUint32(data[4:], 1)
+    data[8], data[9], data[10] = compression, alphaDepth, alphaType
+    le.PutUint32(data[12:], width)
+    le.PutUint32(data[16:], height)
+    le.PutUint32(data[20:], uint32(len(data)))
+    le.PutUint32(data[84:], uint32(len(mip)))
+    copy(data[blp2HeaderSize:], palette)
+    return append(data, mip...)
+}
+
+func TestDecodeBLP(t *testing.T) {
+    // BGRA palette: 0 is a dark color, 1 is red
+    palette := []byte{0x10, 0x20, 0x30, 0, 0, 0, 0xFF, 0}
+    dark, red := color.NRGBA{0x30, 0x20, 0x10, 255}, color.NRGBA{255, 0, 0, 255}
+    blue, black := color.NRGBA{0, 0, 255, 255}, color.NRGBA{0, 0, 0, 255}
+    indices := []byte{0, 1, 1, 0}
+    // DXT color block from red to blue, pixel 0 uses color 1 and the others color 0
+    redBlue := []byte{0x00, 0xF8, 0x1F, 0x00, 1, 0, 0, 0}
+    // DXT1 3-color block from blue to red, pixel 0 uses the transparent color 3
+    threeColor := []byte{0x1F, 0x00, 0x00, 0xF8, 3, 0, 0, 0}
+
+    tests := []struct {
+        name   string
+        data   []byte
+        width  int
+        pixels map[[2]int]color.NRGBA
+    }{
+        {"BLP1 palette", testBLP1(1, 0, 2, 2, palette, indices), 2,
+            map[[2]int]color.NRGBA{{0, 0}: dark, {1, 0}: red, {1, 1}: dark}},
+        {"BLP1 palette 1-bit alpha", testBLP1(1, 1, 2, 2, palette, append(indices, 0x05)), 2,
+            map[[2]int]color.NRGBA{{0, 0}: dark, {1, 0}: {255, 0, 0, 0}, {0, 1}: red}},
+        {"BLP1 palette 8-bit alpha", testBLP1(1, 8, 2, 2, palette, append(indices, 0x80, 0, 0, 0xFF)), 2,
+            map[[2]int]color.NRGBA{{0, 0}: {0x30, 0x20, 0x10, 0x80}, {1, 0}: {255, 0, 0, 0}, {1, 1}: dark}},
+        {"BLP2 palette 4-bit alpha", testBLP2(blpCompressionPalette, 4, 0, 2, 2, palette, append(indices, 0x3F, 0x00)), 2,
+            map[[2]int]color.NRGBA{{0, 0}: dark, {1, 0}: {255, 0, 0, 51}, {0, 1}: {255, 0, 0, 0}}},
+        {"BLP2 BGRA", testBLP2(blpCompressionBGRA, 8, 0, 1, 1, nil, []byte{1, 2, 3, 4}), 1,
+            map[[2]int]color.NRGBA{{0, 0}: {3, 2, 1, 4}}},
+        {"BLP2 DXT1", testBLP2(blpCompressionDXT, 0, 0, 4, 4, nil, redBlue), 4,
+            map[[2]int]color.NRGBA{{0, 0}: blue, {1, 0}: red, {3, 3}: red}},
+        {"BLP2 DXT1 opaque 3-color", testBLP2(blpCompressionDXT, 0, 0, 4, 4, nil, threeColor), 4,
+            map[[2]int]color.NRGBA{{0, 0}: black, {1, 0}: blue}},
+        {"BLP2 DXT1 1-bit alpha", testBLP2(blpCompressionDXT, 1, 0, 4, 4, nil, threeColor), 4,
+            map[[2]int]color.NRGBA{{0, 0}: {}, {1, 0}: blue}},
+        {"BLP2 DXT3", testBLP2(blpCompressionDXT, 8, blpAlphaTypeDXT3, 4, 4, nil,
+            append([]byte{0x5A, 0, 0, 0, 0, 0, 0, 0xF0}, redBlue...)), 4,
+            map[[2]int]color.NRGBA{{0, 0}: {0, 0, 255, 170}, {1, 0}: {255, 0, 0, 85}, {3, 3}: red}},
+        {"BLP2 DXT5", testBLP2(blpCompressionDXT, 8, blpAlphaTypeDXT5, 4, 4, nil,
+            append([]byte{200, 100, 0x01, 0, 0, 0, 0, 0}, redBlue...)), 4,
+            map[[2]int]color.NRGBA{{0, 0}: {0, 0, 255, 100}, {1, 0}: {255, 0, 0, 200}}},
+        // Pixels outside a 2x2 texture are dropped from the 4x4 block
+        {"BLP2 DXT1 partial block", testBLP2(blpCompressionDXT, 0, 0, 2, 2, nil, redBlue), 2,
+            map[[2]int]color.NRGBA{{0, 0}: blue, {1, 1}: red}},
+    }
+    for _, tt := range tests {
+        img, err := decodeBLP(tt.data)
+        if err != nil {
+            t.Errorf("%s: decodeBLP failed: %v", tt.name, err)
+            continue
+        }
+        if w := img.Bounds().Dx(); w != tt.width {
+            t.Errorf("%s: width = %d, want %d", tt.name, w, tt.width)
+        }
+        for p, want := range tt.pixels {
+            if got := img.NRGBAAt(p[0], p[1]); got != want {
+                t.Errorf("%s: pixel %v = %v, want %v", tt.name, p, got, want)
+            }
+        }
+    }
+}
+
+func TestDecodeBLPErrors(t *testing.T) {
+    palette := make([]byte, 4)
+    tests := []struct {
+        name string
+        data []byte
+    }{
+        {"bad magic", []byte("PNG\x00 not a texture")},
+        {"truncated header", []byte("BLP2\x01\x00\x00\x00")},
+        {"BLP1 JPEG", testBLP1(0, 0, 2, 2, nil, []byte{0, 0, 0, 0})},
+        {"BLP1 zero width", testBLP1(1, 0, 0, 2, palette, []byte{0, 0})},
+        {"BLP1 oversized", testBLP1(1, 0, 5000, 2, palette, make([]byte, 10000))},
+        {"BLP2 zero height", testBLP2(blpCompressionBGRA, 8, 0, 2, 0, nil, make([]byte, 16))},
+        {"BLP2 oversized", testBLP2(blpCompressionBGRA, 8, 0, 1<<31, 1<<31, nil, make([]byte, 16))},
+        {"BLP2 unknown compression", testBLP2(9, 8, 0, 1, 1, nil, make([]byte, 4))},
+        {"BLP1 palette data truncated", testBLP1(1, 8, 2, 2, palette, []byte{0, 0, 0, 0, 0xFF})},
+        {"BLP2 BGRA truncated", testBLP2(blpCompressionBGRA, 8, 0, 2, 2, nil, make([]byte, 15))},
+        {"BLP2 DXT truncated", testBLP2(blpCompressionDXT, 8, blpAlphaTypeDXT5, 4, 4, nil, make([]byte, 12))},
+    }
+    for _, tt := range tests {
+        if _, err := decodeBLP(tt.data); err == nil {
+            t.Errorf("%s: decodeBLP succeeded, want an error", tt.name)
+        }
+    }
+
+    // A mipmap pointing past the end of the file
+    data := testBLP2(blpCompressionBGRA, 8, 0, 1, 1, nil, []byte{1, 2, 3, 4})
+    binary.LittleEndian.PutUint32(data[84:], 8)
+    if _, err := decodeBLP(data); err == nil {
+        t.Error("decodeBLP of a mipmap outside the file succeeded")
+    }
+}
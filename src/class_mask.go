@@ -0,0 +1,228 @@
+// Copyright (c) 2025 TalentEditor
+//
+// TalentEditor is licensed under the MIT License.
+// See the LICENSE file for details.
+
+package main
+
+import (
+    "database/sql"
+    "fmt"
+    "sort"
+    "strings"
+
+    "fyne.io/fyne/v2"
+    "fyne.io/fyne/v2/container"
+    "fyne.io/fyne/v2/dialog"
+    "fyne.io/fyne/v2/widget"
+)
+
+// sortedClasses returns the classes ordered by ID
+func sortedClasses(classMap map[int]ChrClass) []ChrClass {
+    classes := make([]ChrClass, 0, len(classMap))
+    for _, c := range classMap {
+        classes = append(classes, c)
+    }
+    sort.Slice(classes, func(i, j int) bool { return classes[i].ID < classes[j].ID })
+    return classes
+}
+
+// unmatchedClassIDs returns the class IDs set in the mask (bit = class ID - 1)
+// that have no ChrClasses row
+func unmatchedClassIDs(mask int64, classMap map[int]ChrClass) []int {
+    var ids []int
+    for bit := 0; bit < 32; bit++ {
+        if mask&(1<<bit) == 0 {
+            continue
+        }
+        if _, ok := classMap[bit+1]; !ok {
+            ids = append(ids, bit+1)
+        }
+    }
+    return ids
+}
+
+// updateTabClassMask stores a new class mask for a tab
+func updateTabClassMask(ctx *AppContext, tabID int, mask int64) error {
+    return runWrite(ctx, func(w *WriteTx) error {
+        _, err := w.Exec("UPDATE TalentTab SET class_mask = ? WHERE id = ?", mask, tabID)
+        return err
+    })
+}
+
+// showClassMaskEditor edits the class mask of the current tab with one checkbox per class.
+// Bits without a ChrClasses row are listed as warnings and can be cleared.
+func showClassMaskEditor(ctx *AppContext) {
+    if ctx.CurrentTab == nil {
+        dialog.ShowInformation(tr("Class Mask"), tr("Select a TalentTab from the left first."), ctx.Window)
+        return
+    }
+    tab := *ctx.CurrentTab
+    classMap, err := GetAllClasses(ctx)
+    if err != nil {
+        dialog.ShowError(err, ctx.Window)
+        return
+    }
+
+    mask := tab.ClassMask.Int64
+    bits := make(map[int]*widget.Check) // class ID -> checkbox
+    grid := container.NewGridWithColumns(2)
+    for _, c := range sortedClasses(classMap) {
+        check := widget.NewCheck(fmt.Sprintf("%d: %s", c.ID, c.NameENUS), nil)
+        check.SetChecked(c.ID >= 1 && c.ID <= 32 && mask&(1<<(c.ID-1)) != 0)
+        bits[c.ID] = check
+        grid.Add(check)
+    }
+    unmatched := unmatchedClassIDs(mask, classMap)
+    for _, id := range unmatched {
+        check := widget.NewCheck(trf("%d: (no ChrClasses row)", id), nil)
+        check.SetChecked(true)
+        bits[id] = check
+        grid.Add(check)
+    }
+
+    current := func() int64 {
+        var m int64
+        for id, check := range bits {
+            if check.Checked && id >= 1 && id <= 32 {
+                m |= 1 << (id - 1)
+            }
+        }
+        return m
+    }
+    value := widget.NewLabel("")
+    value.TextStyle = fyne.TextStyle{Monospace: true}
+    showValue := func() {
+        m := current()
+        value.SetText(fmt.Sprintf("0x%08X = %d", m, m))
+    }
+    for _, check := range bits {
+        check.OnChanged = func(bool) { showValue() }
+    }
+    showValue()
+
+    top := container.NewVBox(value)
+    if len(unmatched) > 0 {
+        ids := make([]string, len(unmatched))
+        for i, id := range unmatched {
+            ids[i] = trf("bit %d (class %d)", id-1, id)
+        }
+        warning := widget.NewLabel(trf("Warning: %s match no ChrClasses row.", strings.Join(ids, ", ")))
+        warning.Wrapping = fyne.TextWrapWord
+        warning.Importance = widget.WarningImportance
+        top.Add(warning)
+    }
+
+    scroll := container.NewVScroll(grid)
+    scroll.SetMinSize(fyne.NewSize(450, 300))
+    content := container.NewBorder(top, nil, nil, nil, scroll)
+
+    title := trf("Class Mask - %s", tab.NameENUS)
+    if ctx.ReadOnly {
+        for _, check := range bits {
+            check.Disable()
+        }
+        dialog.ShowCustom(title, tr("Close"), content, ctx.Window)
+        return
+    }
+
+    dialog.ShowCustomConfirm(title, tr("Save"), tr("Cancel"), content, func(ok bool) {
+        if !ok {
+            return
+        }
+        m := current()
+        if err := updateTabClassMask(ctx, tab.ID, m); err != nil {
+            dialog.ShowError(err, ctx.Window)
+            return
+        }
+        if ctx.Patch != nil {
+            dialog.ShowInformation(tr("Class Mask"), tr("The tab list shows the new class mask once the SQL patch has been applied."), ctx.Window)
+            return
+        }
+        if ctx.CurrentTab != nil && ctx.CurrentTab.ID == tab.ID {
+            ctx.CurrentTab.ClassMask = sql.NullInt64{Int64: m, Valid: true}
+        }
+        reloadTabs(ctx)
+    }, ctx.Window)
+}
+
+// classAssignmentsReport lists the tabs of each class, the tabs shared by several
+// classes and the tabs whose class mask does not resolve to any class
+func classAssignmentsReport(tabs map[int]TalentTab, classMap map[int]ChrClass) string {
+    ids := make([]int, 0, len(tabs))
+    for id := range tabs {
+        ids = append(ids, id)
+    }
+    sort.Ints(ids)
+
+    var sb strings.Builder
+    sb.WriteString(tr("Tabs per class:") + "\n")
+    for _, c := range sortedClasses(classMap) {
+        var names []string
+        for _, id := range ids {
+            t := tabs[id]
+            if !isPetTab(t) && c.ID >= 1 && c.ID <= 32 && t.ClassMask.Int64&(1<<(c.ID-1)) != 0 {
+                names = append(names, fmt.Sprintf("%s (%d)", t.NameENUS, t.ID))
+            }
+        }
+        if len(names) == 0 {
+            names = []string{"-"}
+        }
+        fmt.Fprintf(&sb, "  %d %s: %s\n", c.ID, c.NameENUS, strings.Join(names, ", "))
+    }
+
+    var shared, problems []string
+    for _, id := range ids {
+        t := tabs[id]
+        if isPetTab(t) {
+            continue
+        }
+        var classes []string
+        for _, c := range sortedClasses(classMap) {
+            if c.ID >= 1 && c.ID <= 32 && t.ClassMask.Int64&(1<<(c.ID-1)) != 0 {
+                classes = append(classes, c.NameENUS)
+            }
+        }
+        if len(classes) > 1 {
+            shared = append(shared, fmt.Sprintf("  %s (%d): %s", t.NameENUS, t.ID, strings.Join(classes, ", ")))
+        }
+        if unmatched := unmatchedClassIDs(t.ClassMask.Int64, classMap); len(unmatched) > 0 {
+            problems = append(problems, trf("  %s (%d): class IDs %s have no ChrClasses row",
+                t.NameENUS, t.ID, strings.Trim(fmt.Sprint(unmatched), "[]")))
+        } else if len(classes) == 0 {
+            problems = append(problems, trf("  %s (%d): class mask %d matches no class", t.NameENUS, t.ID, t.ClassMask.Int64))
+        }
+    }
+
+    sb.WriteString("\n" + trf("Tabs shared by several classes (%d):", len(shared)) + "\n")
+    for _, line := range shared {
+        sb.WriteString(line + "\n")
+    }
+    sb.WriteString("\n" + trf("Class mask warnings (%d):", len(problems)) + "\n")
+    for _, line := range problems {
+        sb.WriteString(line + "\n")
+    }
+    return sb.String()
+}
+
+// showClassAssignments opens a window with the class assignments report
+func showClassAssignments(ctx *AppContext) {
+    tabs, err := GetAllTalentTabs(ctx)
+    if err != nil {
+        dialog.ShowError(err, ctx.Window)
+        return
+    }
+    classMap, err := GetAllClasses(ctx)
+    if err != nil {
+        dialog.ShowError(err, ctx.Window)
+        return
+    }
+
+    report := widget.NewLabel(classAssignmentsReport(tabs, classMap))
+    report.TextStyle = fyne.TextStyle{Monospace: true}
+
+    w := fyne.CurrentApp().NewWindow(tr("Class Tab Assignments"))
+    w.SetContent(container.NewVScroll(report))
+    w.Resize(fyne.NewSize(700, 600))
+    w.Show()
+}
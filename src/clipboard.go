@@ -0,0 +1,333 @@
+// Copyright (c) 2025 TalentEditor
+//
+// TalentEditor is licensed under the MIT License.
+// See the LICENSE file for details.
+
+package main
+
+import (
+    "database/sql"
+    "errors"
+    "fmt"
+    "sort"
+    "strings"
+
+    "fyne.io/fyne/v2"
+    "fyne.io/fyne/v2/dialog"
+    "fyne.io/fyne/v2/widget"
+)
+
+// TalentClipboard holds copied or cut talents. Pasting copied talents creates new
+// talents with fresh IDs, pasting cut talents moves them and keeps their IDs.
+type TalentClipboard struct {
+    Talents []Talent
+    Cut     bool
+}
+
+// copyTalents puts the talents on the clipboard
+func copyTalents(ctx *AppContext, talents []Talent, cut bool) {
+    if len(talents) == 0 {
+        return
+    }
+    ctx.Clipboard = &TalentClipboard{Talents: append([]Talent(nil), talents...), Cut: cut}
+}
+
+// placeTalents positions the talents in the target tab so that the top left corner of
+// their bounding box lands on row/col. Prerequisites pointing outside the set are
+// cleared unless the required talent lives in the target tab.
+func placeTalents(talents []Talent, all []Talent, tabID, row, col int, moving bool) ([]Talent, error) {
+    minTier, minCol := talentsAnchor(talents)
+    inSet := make(map[int]bool)
+    for _, t := range talents {
+        inSet[t.ID] = true
+    }
+
+    byID := make(map[int]*Talent, len(all))
+    occupied := make(map[[2]int]int)
+    for i := range all {
+        t := &all[i]
+        byID[t.ID] = t
+        if t.SpecID.Valid && int(t.SpecID.Int64) == tabID && !(moving && inSet[t.ID]) {
+            occupied[[2]int{int(t.TierID.Int64), int(t.ColumnIndex.Int64)}] = t.ID
+        }
+    }
+
+    placed := make([]Talent, 0, len(talents))
+    for _, t := range talents {
+        tier := int(t.TierID.Int64) - minTier + row
+        column := int(t.ColumnIndex.Int64) - minCol + col
+        if tier < 0 || tier >= MAX_NUM_TALENT_TIERS || column < 0 || column >= NUM_TALENT_COLUMNS {
+            return nil, errors.New(trf("talent %d would be placed outside the grid (tier %d, column %d)", t.ID, tier+1, column+1))
+        }
+        if other, ok := occupied[[2]int{tier, column}]; ok {
+            return nil, errors.New(trf("tier %d, column %d is already taken by talent %d", tier+1, column+1, other))
+        }
+        occupied[[2]int{tier, column}] = t.ID
+
+        t.SpecID = sql.NullInt64{Int64: int64(tabID), Valid: true}
+        t.TierID = sql.NullInt64{Int64: int64(tier), Valid: true}
+        t.ColumnIndex = sql.NullInt64{Int64: int64(column), Valid: true}
+
+        for i, p := range t.PreReqTalent {
+            if !p.Valid || p.Int64 == 0 || inSet[int(p.Int64)] {
+                continue
+            }
+            if req, ok := byID[int(p.Int64)]; ok && req.SpecID.Valid && int(req.SpecID.Int64) == tabID {
+                continue
+            }
+            t.PreReqTalent[i] = sql.NullInt64{Int64: 0, Valid: true}
+            t.PreReqRank[i] = sql.NullInt64{Int64: 0, Valid: true}
+        }
+        placed = append(placed, t)
+    }
+    return placed, nil
+}
+
+// talentsAnchor returns the top left corner of the bounding box of the talents
+func talentsAnchor(talents []Talent) (int, int) {
+    minTier, minCol := MAX_NUM_TALENT_TIERS, NUM_TALENT_COLUMNS
+    for _, t := range talents {
+        minTier = min(minTier, int(t.TierID.Int64))
+        minCol = min(minCol, int(t.ColumnIndex.Int64))
+    }
+    return minTier, minCol
+}
+
+// remapTalentIDs assigns new IDs to copied talents and rewrites prerequisites within the set
+func remapTalentIDs(talents []Talent, ids []int) {
+    mapping := make(map[int]int, len(talents))
+    for i := range talents {
+        mapping[talents[i].ID] = ids[i]
+    }
+    for i := range talents {
+        talents[i].ID = ids[i]
+        for j, p := range talents[i].PreReqTalent {
+            if newID, ok := mapping[int(p.Int64)]; ok && p.Valid {
+                talents[i].PreReqTalent[j] = sql.NullInt64{Int64: int64(newID), Valid: true}
+            }
+        }
+    }
+}
+
+// pasteTalents places the clipboard talents into a tab at row/col in one transaction
+func pasteTalents(ctx *AppContext, clip *TalentClipboard, tabID, row, col int) error {
+    all, err := loadAllTalents(ctx)
+    if err != nil {
+        return err
+    }
+
+    talents := clip.Talents
+    if clip.Cut {
+        // Move the current state of the cut talents, not the state at the time of the cut
+        current := make(map[int]Talent, len(all))
+        for _, t := range all {
+            current[t.ID] = t
+        }
+        talents = nil
+        for _, t := range clip.Talents {
+            fresh, ok := current[t.ID]
+            if !ok {
+                return fmt.Errorf("cut talent %d no longer exists", t.ID)
+            }
+            talents = append(talents, fresh)
+        }
+    }
+
+    placed, err := placeTalents(talents, all, tabID, row, col, clip.Cut)
+    if err != nil {
+        return err
+    }
+    var stranded []Talent
+    if clip.Cut {
+        stranded = strandedDependents(all, placed, tabID)
+    }
+
+    return runWrite(ctx, func(w *WriteTx) error {
+        if !clip.Cut {
+            ids, err := allocateTalentIDs(ctx, w, len(placed))
+            if err != nil {
+                return err
+            }
+            remapTalentIDs(placed, ids)
+        }
+
+        for i := range placed {
+            t := &placed[i]
+            query, args := InsertTalentQuery(t)
+            if clip.Cut {
+                query, args = UpdateTalentQuery(t)
+            }
+            if _, err := w.Exec(query, args...); err != nil {
+                if clip.Cut {
+                    return err
+                }
+                return idInUseError(err, "talent", t.ID)
+            }
+            w.TrackTalent(t.ID, t)
+        }
+        for i := range stranded {
+            t := &stranded[i]
+            query, args := UpdateTalentQuery(t)
+            if _, err := w.Exec(query, args...); err != nil {
+                return err
+            }
+            w.TrackTalent(t.ID, t)
+        }
+        return nil
+    })
+}
+
+// strandedDependents returns the talents outside the target tab that require one of the
+// moved talents, with those prerequisites cleared the way deleting a talent clears them,
+// as prerequisites cannot point into another tab
+func strandedDependents(all []Talent, moved []Talent, tabID int) []Talent {
+    inSet := make(map[int]bool, len(moved))
+    for _, t := range moved {
+        inSet[t.ID] = true
+    }
+    var updated []Talent
+    for _, t := range all {
+        if inSet[t.ID] || (t.SpecID.Valid && int(t.SpecID.Int64) == tabID) {
+            continue
+        }
+        var slots []int
+        for i, p := range t.PreReqTalent {
+            if p.Valid && inSet[int(p.Int64)] {
+                slots = append(slots, i)
+            }
+        }
+        if len(slots) > 0 {
+            repointPrereqs(&t, slots, 0)
+            updated = append(updated, t)
+        }
+    }
+    return updated
+}
+
+// showCellMenu opens the context menu of a talent grid cell
+func showCellMenu(ctx *AppContext, tab TalentTab, talent *Talent, row, col int, pos fyne.Position, reloadTab func()) {
+    var items []*fyne.MenuItem
+
+    if talent != nil {
+        // A right click inside the selection acts on all selected talents
+        targets := []Talent{*talent}
+        copyLabel, cutLabel, duplicateLabel := tr("Copy"), tr("Cut"), tr("Duplicate To Tab...")
+        if sel := selectedTalents(ctx); len(sel) > 1 && isSelected(ctx, talent.ID) {
+            targets = sel
+            copyLabel = trf("Copy %d Talents", len(sel))
+            cutLabel = trf("Cut %d Talents", len(sel))
+            duplicateLabel = trf("Duplicate %d Talents To Tab...", len(sel))
+        }
+        copyItem := fyne.NewMenuItem(copyLabel, func() { copyTalents(ctx, targets, false) })
+        cutItem := fyne.NewMenuItem(cutLabel, func() { copyTalents(ctx, targets, true) })
+        duplicateItem := fyne.NewMenuItem(duplicateLabel, func() { showDuplicateDialog(ctx, targets, reloadTab) })
+        cutItem.Disabled = ctx.ReadOnly
+        duplicateItem.Disabled = ctx.ReadOnly
+        items = append(items, copyItem, cutItem, duplicateItem)
+        if len(targets) > 1 {
+            ids := make([]int, len(targets))
+            for i, t := range targets {
+                ids[i] = t.ID
+            }
+            renumberItem := fyne.NewMenuItem(trf("Renumber %d Talents...", len(targets)), func() { showRenumberDialog(ctx, ids) })
+            renumberItem.Disabled = ctx.ReadOnly
+            items = append(items, renumberItem)
+        }
+    } else {
+        label := tr("Paste")
+        if ctx.Clipboard != nil {
+            label = trn(len(ctx.Clipboard.Talents), "Paste %d Talent", "Paste %d Talents")
+        }
+        pasteItem := fyne.NewMenuItem(label, func() {
+            clip := ctx.Clipboard
+            if err := pasteTalents(ctx, clip, tab.ID, row, col); err != nil {
+                dialog.ShowError(err, ctx.Window)
+                return
+            }
+            if clip.Cut {
+                ctx.Clipboard = nil
+            }
+            reloadTab()
+        })
+        pasteItem.Disabled = ctx.ReadOnly || ctx.Clipboard == nil
+        items = append(items, pasteItem)
+    }
+
+    gridItem := fyne.NewMenuItem(tr("Grid"), nil)
+    gridItem.ChildMenu = gridOpsMenu(ctx, tab, row, col, reloadTab)
+
+    items = append(items,
+        fyne.NewMenuItemSeparator(),
+        fyne.NewMenuItem(tr("Copy All Talents In Tab"), func() { copyTabTalents(ctx, tab) }),
+        gridItem,
+    )
+
+    widget.ShowPopUpMenuAtPosition(fyne.NewMenu("", items...), ctx.Window.Canvas(), pos)
+}
+
+// copyTabTalents puts every talent of the tab on the clipboard
+func copyTabTalents(ctx *AppContext, tab TalentTab) {
+    all, err := loadAllTalents(ctx)
+    if err != nil {
+        dialog.ShowError(err, ctx.Window)
+        return
+    }
+    var talents []Talent
+    for _, t := range all {
+        if t.SpecID.Valid && int(t.SpecID.Int64) == tab.ID {
+            talents = append(talents, t)
+        }
+    }
+    copyTalents(ctx, talents, false)
+}
+
+// tabOptions lists all tabs as "[Class] Name (ID)" select options, sorted, with the tab ID per option
+func tabOptions(ctx *AppContext) ([]string, map[string]int, error) {
+    tabs, err := GetAllTalentTabs(ctx)
+    if err != nil {
+        return nil, nil, err
+    }
+    classMap, err := GetAllClasses(ctx)
+    if err != nil {
+        return nil, nil, err
+    }
+
+    labels := make(map[string]int)
+    var options []string
+    for _, tab := range tabs {
+        group := tr("Pet")
+        if !isPetTab(tab) {
+            group = strings.Join(tabClassNames(tab, classMap), "/")
+        }
+        label := fmt.Sprintf("[%s] %s (%d)", group, tab.NameENUS, tab.ID)
+        labels[label] = tab.ID
+        options = append(options, label)
+    }
+    sort.Strings(options)
+    return options, labels, nil
+}
+
+// showDuplicateDialog copies talents into another tab at their current grid positions
+func showDuplicateDialog(ctx *AppContext, talents []Talent, reloadTab func()) {
+    options, labels, err := tabOptions(ctx)
+    if err != nil {
+        dialog.ShowError(err, ctx.Window)
+        return
+    }
+    tabSelect := widget.NewSelect(options, nil)
+
+    items := []*widget.FormItem{widget.NewFormItem(tr("Target tab"), tabSelect)}
+    dialog.ShowForm(tr("Duplicate To Tab"), tr("Duplicate"), tr("Cancel"), items, func(ok bool) {
+        if !ok || tabSelect.Selected == "" {
+            return
+        }
+
+        minTier, minCol := talentsAnchor(talents)
+        clip := &TalentClipboard{Talents: talents}
+        if err := pasteTalents(ctx, clip, labels[tabSelect.Selected], minTier, minCol); err != nil {
+            dialog.ShowError(err, ctx.Window)
+            return
+        }
+        reloadTab()
+    }, ctx.Window)
+}
@@ -0,0 +1,159 @@
+// Copyright (c) 2025 TalentEditor
+//
+// TalentEditor is licensed under the MIT License.
+// See the LICENSE file for details.
+
+package main
+
+import (
+    "fmt"
+    "strings"
+
+    "fyne.io/fyne/v2"
+    "fyne.io/fyne/v2/canvas"
+    "fyne.io/fyne/v2/container"
+    "fyne.io/fyne/v2/dialog"
+    "fyne.io/fyne/v2/widget"
+
+    fynetooltip "github.com/dweymouth/fyne-tooltip"
+)
+
+// openCompareDB returns the connection to the compare database from config.json, opening it on first use
+func openCompareDB(ctx *AppContext) (*AppContext, error) {
+    if ctx.Config == nil || ctx.Config.Compare == nil {
+        return nil, fmt.Errorf("no \"compare\" database configured in config.json")
+    }
+    if ctx.CompareDB == nil {
+        db, err := openDB(*ctx.Config.Compare)
+        if err != nil {
+            return nil, fmt.Errorf("failed to open compare DB: %w", err)
+        }
+        ctx.CompareDB = db
+    }
+    return &AppContext{DB: ctx.CompareDB, Window: ctx.Window}, nil
+}
+
+// compareCurrentTab diffs the selected tab of the live database against the given old data state
+func compareCurrentTab(ctx *AppContext, oldData *TalentData) {
+    if ctx.CurrentTab == nil {
+        dialog.ShowInformation(tr("Compare"), tr("Select a TalentTab from the left first."), ctx.Window)
+        return
+    }
+
+    newData, err := loadTalentData(ctx, ctx.Config.DBC.Name)
+    if err != nil {
+        dialog.ShowError(err, ctx.Window)
+        return
+    }
+
+    openCompareWindow(ctx, *ctx.CurrentTab, oldData, newData)
+}
+
+// openCompareWindow renders a tab from two data states side by side, with changed talents
+// color-coded on both grids and the field-level differences listed on the right
+func openCompareWindow(ctx *AppContext, tab TalentTab, oldData, newData *TalentData) {
+    iconIDs, err := GetAllSpellIcons(ctx)
+    if err != nil {
+        dialog.ShowError(err, ctx.Window)
+        return
+    }
+
+    oldTalents := oldData.TalentsForTab(tab.ID)
+    newTalents := newData.TalentsForTab(tab.ID)
+    changes := diffTalents(oldTalents, newTalents)
+
+    makeGrid := func(data *TalentData, talents []Talent) (*fyne.Container, map[int]*TalentButton) {
+        return buildTalentGrid(talents, func(t *Talent, r, c int, buttonSize fyne.Size) *TalentButton {
+            icon, tooltip := talentIconAndTooltip(t, iconIDs, buttonSize, data.Spells)
+            return NewTalentButton(icon, buttonSize, tooltip, nil)
+        })
+    }
+    oldGrid, oldButtons := makeGrid(oldData, oldTalents)
+    newGrid, newButtons := makeGrid(newData, newTalents)
+
+    for _, c := range changes {
+        if btn, ok := oldButtons[c.ID]; ok && c.Kind != ChangeAdded {
+            btn.SetHighlight(c.Kind.Color())
+        }
+        if btn, ok := newButtons[c.ID]; ok && c.Kind != ChangeRemoved {
+            btn.SetHighlight(c.Kind.Color())
+        }
+    }
+
+    titled := func(title string, grid *fyne.Container) fyne.CanvasObject {
+        lbl := widget.NewLabel(title)
+        lbl.TextStyle = fyne.TextStyle{Bold: true}
+        return container.NewBorder(container.NewCenter(lbl), nil, nil, nil, container.NewCenter(grid))
+    }
+    grids := container.NewHBox(
+        titled(trf("Old: %s", oldData.Name), oldGrid),
+        titled(trf("New: %s", newData.Name), newGrid),
+    )
+
+    // Legend of change colors
+    legend := container.NewHBox()
+    for _, k := range []ChangeKind{ChangeAdded, ChangeRemoved, ChangeMoved, ChangeChanged} {
+        txt := canvas.NewText(tr(k.String()), k.Color())
+        txt.TextStyle = fyne.TextStyle{Bold: true}
+        legend.Add(txt)
+    }
+
+    summary := widget.NewRichTextFromMarkdown(changesMarkdown(changes, oldData, newData))
+    summary.Wrapping = fyne.TextWrapWord
+
+    split := container.NewHSplit(container.NewScroll(grids), container.NewVScroll(summary))
+    split.Offset = 0.7
+
+    w := fyne.CurrentApp().NewWindow(trf("Compare - %s", tab.NameENUS))
+    content := container.NewBorder(container.NewCenter(legend), nil, nil, nil, split)
+    w.SetContent(fynetooltip.AddWindowToolTipLayer(content, w.Canvas()))
+    w.Resize(fyne.NewSize(1400, 1000))
+    w.Show()
+}
+
+// changesMarkdown lists each changed talent with its field-level differences
+func changesMarkdown(changes []TalentChange, oldData, newData *TalentData) string {
+    if len(changes) == 0 {
+        return tr("No differences.")
+    }
+
+    var sb strings.Builder
+    for _, c := range changes {
+        var name string
+        if c.New != nil {
+            name = talentName(c.New, newData.Spells)
+        } else {
+            name = talentName(c.Old, oldData.Spells)
+        }
+        fmt.Fprintf(&sb, "**%s** %s (ID %d)\n\n", tr(c.Kind.String()), name, c.ID)
+        for _, f := range c.Fields {
+            fmt.Fprintf(&sb, "* %s: %s → %s\n", f.Label, f.Old, f.New)
+        }
+        if len(c.Fields) > 0 {
+            sb.WriteString("\n")
+        }
+    }
+    return sb.String()
+}
+
+// talentName returns the enUS first-rank spell name of a talent, or a placeholder when
+// unknown. Diffs and patch notes use it, the names do not depend on the display locale.
+func talentName(t *Talent, spells map[int]Spell) string {
+    if t.Rank[0].Valid {
+        if spell, ok := spells[int(t.Rank[0].Int64)]; ok && spell.NameENUS != "" {
+            return spell.NameENUS
+        }
+    }
+    return fmt.Sprintf("Talent %d", t.ID)
+}
+
+// talentDisplayName returns the first-rank spell name of a talent in the display locale
+// for on-screen labels, or a translated placeholder when unknown
+func talentDisplayName(t *Talent, spells map[int]Spell) string {
+    if t.Rank[0].Valid {
+        if spell, ok := spells[int(t.Rank[0].Int64)]; ok && spell.DisplayName() != "" {
+            return spell.DisplayName()
+        }
+    }
+    return trf("Talent %d", t.ID)
+}
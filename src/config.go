@@ -13,16 +13,54 @@ import (
 
 // DBConfig holds config for a single database
 type DBConfig struct {
-    User     string `json:"user"`
-    Password string `json:"password"`
-    Host     string `json:"host"`
-    Port     string `json:"port"`
-    Name     string `json:"name"`
+    User     string   `json:"user"`
+    Password string   `json:"password"`
+    Host     string   `json:"host"`
+    Port     string   `json:"port"`
+    Name     string   `json:"name"`
+    ReadOnly bool     `json:"read_only,omitempty"` // refuse all writes on this connection
+    IDRange  *IDRange `json:"id_range,omitempty"`  // talent IDs reserved for this connection profile
+}
+
+// IDRange is an inclusive range of talent IDs
+type IDRange struct {
+    Min int `json:"min"`
+    Max int `json:"max"`
+}
+
+// IDAllocationConfig selects how IDs for new talents are chosen
+type IDAllocationConfig struct {
+    Strategy string             `json:"strategy,omitempty"` // "atomic" (default), "range" or "lowest_gap"
+    User     string             `json:"user,omitempty"`     // key into Ranges, defaults to the OS user name
+    Ranges   map[string]IDRange `json:"ranges,omitempty"`   // talent IDs reserved per user
+}
+
+// IconConfig lists the directories searched for icons before the embedded icons.zip
+type IconConfig struct {
+    SearchPaths []string `json:"search_paths,omitempty"` // .blp and .png files named like the SpellIcon texture
+}
+
+// BackgroundConfig locates the talent tab background textures
+type BackgroundConfig struct {
+    Folder string `json:"folder,omitempty"` // e.g. an extracted Interface\TalentFrame with MageFire-TopLeft.blp
+}
+
+// LocaleConfig selects the client locales shown and checked for translations, and the
+// language of the user interface
+type LocaleConfig struct {
+    Display  string   `json:"display,omitempty"`  // locale of tab names and spell texts, defaults to enUS
+    Required []string `json:"required,omitempty"` // locales checked by the missing translations report
+    UI       string   `json:"ui,omitempty"`       // "en" or "de", defaults to the language of the OS
 }
 
 // Config is the root config.json structure
 type Config struct {
-    DBC     DBConfig     `json:"dbc"`
+    DBC          DBConfig           `json:"dbc"`
+    Compare      *DBConfig          `json:"compare,omitempty"` // optional second database, e.g. stock 3.3.5 DBC data
+    IDAllocation IDAllocationConfig `json:"id_allocation"`
+    Icons        IconConfig         `json:"icons"`
+    Backgrounds  BackgroundConfig   `json:"backgrounds"`
+    Locale       LocaleConfig       `json:"locale"`
 }
 
 // loadOrInitConfig loads config.json, or generates a template if missing
@@ -30,7 +68,8 @@ func loadOrInitConfig(path string) (*Config, bool, error) {
     if _, err := os.Stat(path); os.IsNotExist(err) {
         // Create template config
         template := Config{
-            DBC: DBConfig{"root", "password", "127.0.0.1", "3306", "dbc"},
+            DBC:          DBConfig{User: "root", Password: "password", Host: "127.0.0.1", Port: "3306", Name: "dbc"},
+            IDAllocation: IDAllocationConfig{Strategy: AllocAtomic},
         }
 
         data, err := json.MarshalIndent(template, "", "  ")
@@ -7,16 +7,24 @@ package main
 
 import (
     "database/sql"
+    "errors"
     "fmt"
     "strings"
+
+    "github.com/go-sql-driver/mysql"
 )
 
 // TalentTab queries
 func GetAllTalentTabs(ctx *AppContext) (map[int]TalentTab, error) {
-    query := `
-        SELECT id, name_enus, spell_icon, class_mask, order_index, background_file, creature_family
+    otherLocales := clientLocales[1:]
+    nameColumns := make([]string, len(otherLocales))
+    for i, l := range otherLocales {
+        nameColumns[i] = localeColumn("name", l)
+    }
+    query := fmt.Sprintf(`
+        SELECT id, name_enus, %s, spell_icon, class_mask, order_index, background_file, creature_family
         FROM TalentTab
-        ORDER BY id`
+        ORDER BY id`, strings.Join(nameColumns, ", "))
     
     rows, err := queryWithDebug(ctx.DB, query)
     if err != nil {
@@ -28,7 +36,13 @@ func GetAllTalentTabs(ctx *AppContext) (map[int]TalentTab, error) {
     for rows.Next() {
         var t TalentTab
         var name sql.NullString
-        if err := rows.Scan(&t.ID, &name, &t.SpellIcon, &t.ClassMask, &t.OrderIndex, &t.Background, &t.CreatureFamily,); err != nil {
+        otherNames := make([]sql.NullString, len(otherLocales))
+        dest := []interface{}{&t.ID, &name}
+        for i := range otherNames {
+            dest = append(dest, &otherNames[i])
+        }
+        dest = append(dest, &t.SpellIcon, &t.ClassMask, &t.OrderIndex, &t.Background, &t.CreatureFamily)
+        if err := rows.Scan(dest...); err != nil {
             return nil, err
         }
 
@@ -37,6 +51,10 @@ func GetAllTalentTabs(ctx *AppContext) (map[int]TalentTab, error) {
         } else {
             t.NameENUS = fmt.Sprintf("tab_%d", t.ID)
         }
+        t.OtherLanguage = make(map[string]sql.NullString, len(otherLocales))
+        for i, l := range otherLocales {
+            t.OtherLanguage[l] = otherNames[i]
+        }
 
         tabs[t.ID] = t
     }
@@ -48,6 +66,32 @@ func GetAllTalentTabs(ctx *AppContext) (map[int]TalentTab, error) {
     return tabs, nil
 }
 
+// UpdateTabNamesQuery writes the name of a tab in every client locale
+func UpdateTabNamesQuery(tabID int, names map[string]string) (string, []interface{}) {
+    sets := make([]string, len(clientLocales))
+    args := make([]interface{}, 0, len(clientLocales)+1)
+    for i, l := range clientLocales {
+        sets[i] = localeColumn("name", l) + " = ?"
+        args = append(args, names[l])
+    }
+    args = append(args, tabID)
+    return fmt.Sprintf("UPDATE TalentTab SET %s WHERE id = ?", strings.Join(sets, ", ")), args
+}
+
+// CloneTabQuery gives the copied row in talent_tab_clone its new ID and values. The
+// name is set in every client locale so no locale keeps the name of the source tab.
+func CloneTabQuery(newTabID int, opts CloneTabOptions) (string, []interface{}) {
+    sets := []string{"id = ?"}
+    args := []interface{}{newTabID}
+    for _, l := range clientLocales {
+        sets = append(sets, localeColumn("name", l)+" = ?")
+        args = append(args, opts.Name)
+    }
+    sets = append(sets, "class_mask = ?", "order_index = ?")
+    args = append(args, opts.ClassMask, opts.OrderIndex)
+    return "UPDATE talent_tab_clone SET " + strings.Join(sets, ", "), args
+}
+
 // Spell queries
 func GetSpellsByIDs(ctx *AppContext, ids []int) (map[int]Spell, error) {
     result := make(map[int]Spell)
@@ -83,10 +127,15 @@ func GetSpellsByIDs(ctx *AppContext, ids []int) (map[int]Spell, error) {
         args[i] = id
     }
 
+    // Texts in the display locale are loaded next to enUS, which is the fallback
+    localColumns := "'', ''"
+    if ctx.Locale != "" && ctx.Locale != defaultLocale {
+        localColumns = localeColumn("spell_name", ctx.Locale) + ", " + localeColumn("spell_desc", ctx.Locale)
+    }
     query := fmt.Sprintf(`
-        SELECT id, spell_name_enus, spell_icon_id, spell_desc_enus
+        SELECT id, spell_name_enus, spell_icon_id, spell_desc_enus, %s
         FROM Spell
-        WHERE id IN (%s)`, strings.Join(placeholders, ","))
+        WHERE id IN (%s)`, localColumns, strings.Join(placeholders, ","))
 
     rows, err := queryWithDebug(ctx.DB, query, args...)
     if err != nil {
@@ -96,9 +145,11 @@ func GetSpellsByIDs(ctx *AppContext, ids []int) (map[int]Spell, error) {
 
     for rows.Next() {
         var s Spell
-        if err := rows.Scan(&s.ID, &s.NameENUS, &s.IconID, &s.Desc); err != nil {
+        var localName, localDesc sql.NullString
+        if err := rows.Scan(&s.ID, &s.NameENUS, &s.IconID, &s.Desc, &localName, &localDesc); err != nil {
             return nil, err
         }
+        s.LocalName, s.LocalDesc = localName.String, localDesc.String
 
         // Update cache and result
         ctx.Spells[s.ID] = s
@@ -108,14 +159,261 @@ func GetSpellsByIDs(ctx *AppContext, ids []int) (map[int]Spell, error) {
     return result, nil
 }
 
+// Spell effect columns needed to render description tokens
+const spellEffectColumns = `id,
+    effect_base_points_1, effect_base_points_2, effect_base_points_3,
+    effect_die_sides_1, effect_die_sides_2, effect_die_sides_3,
+    effect_amplitude_1, effect_amplitude_2, effect_amplitude_3,
+    effect_radius_index_1, effect_radius_index_2, effect_radius_index_3,
+    effect_chain_target_1, effect_chain_target_2, effect_chain_target_3,
+    effect_misc_value_1, effect_misc_value_2, effect_misc_value_3,
+    effect_points_per_combo_point_1, effect_points_per_combo_point_2, effect_points_per_combo_point_3,
+    effect_value_multiplier_1, effect_value_multiplier_2, effect_value_multiplier_3,
+    duration_index, proc_chance, proc_charges, stack_amount, max_target_level, max_affected_targets`
+
+// GetSpellEffects loads the effect data of spells, cached like GetSpellsByIDs.
+// IDs without a Spell row are cached with Exists false.
+func GetSpellEffects(ctx *AppContext, ids []int) (map[int]SpellEffects, error) {
+    result := make(map[int]SpellEffects)
+    if ctx.SpellEffects == nil {
+        ctx.SpellEffects = make(map[int]SpellEffects)
+    }
+
+    var missing []int
+    for _, id := range ids {
+        if e, ok := ctx.SpellEffects[id]; ok {
+            result[id] = e
+        } else {
+            missing = append(missing, id)
+        }
+    }
+    if len(missing) == 0 {
+        return result, nil
+    }
+
+    placeholders := make([]string, len(missing))
+    args := make([]interface{}, len(missing))
+    for i, id := range missing {
+        placeholders[i] = "?"
+        args[i] = id
+    }
+
+    query := fmt.Sprintf("SELECT %s FROM Spell WHERE id IN (%s)", spellEffectColumns, strings.Join(placeholders, ","))
+    rows, err := queryWithDebug(ctx.DB, query, args...)
+    if err != nil {
+        return nil, err
+    }
+    defer rows.Close()
+
+    for rows.Next() {
+        var e SpellEffects
+        dest := []interface{}{&e.ID}
+        for _, field := range []*[3]int64{&e.BasePoints, &e.DieSides, &e.Amplitude, &e.RadiusIndex, &e.ChainTargets, &e.MiscValue} {
+            for i := range field {
+                dest = append(dest, &field[i])
+            }
+        }
+        for _, field := range []*[3]float64{&e.PointsPerCombo, &e.Multiplier} {
+            for i := range field {
+                dest = append(dest, &field[i])
+            }
+        }
+        dest = append(dest, &e.DurationIndex, &e.ProcChance, &e.ProcCharges, &e.StackAmount, &e.MaxTargetLevel, &e.MaxTargets)
+        if err := rows.Scan(dest...); err != nil {
+            return nil, err
+        }
+        e.Exists = true
+        ctx.SpellEffects[e.ID] = e
+        result[e.ID] = e
+    }
+    if err := rows.Err(); err != nil {
+        return nil, err
+    }
+
+    for _, id := range missing {
+        if _, ok := result[id]; !ok {
+            ctx.SpellEffects[id] = SpellEffects{ID: id}
+            result[id] = ctx.SpellEffects[id]
+        }
+    }
+    return result, nil
+}
+
+// GetSpellDurations reads the SpellDuration table
+func GetSpellDurations(ctx *AppContext) (map[int]SpellDuration, error) {
+    if ctx.SpellDurations != nil {
+        return ctx.SpellDurations, nil
+    }
+
+    rows, err := queryWithDebug(ctx.DB, "SELECT id, duration, duration_per_level, max_duration FROM SpellDuration")
+    if err != nil {
+        return nil, err
+    }
+    defer rows.Close()
+
+    durations := make(map[int]SpellDuration)
+    for rows.Next() {
+        var d SpellDuration
+        if err := rows.Scan(&d.ID, &d.Duration, &d.PerLevel, &d.Max); err != nil {
+            return nil, err
+        }
+        durations[d.ID] = d
+    }
+    if err := rows.Err(); err != nil {
+        return nil, err
+    }
+
+    ctx.SpellDurations = durations
+    return durations, nil
+}
+
+// GetSpellRadii reads the SpellRadius table
+func GetSpellRadii(ctx *AppContext) (map[int]SpellRadius, error) {
+    if ctx.SpellRadii != nil {
+        return ctx.SpellRadii, nil
+    }
+
+    rows, err := queryWithDebug(ctx.DB, "SELECT id, radius, radius_per_level, radius_max FROM SpellRadius")
+    if err != nil {
+        return nil, err
+    }
+    defer rows.Close()
+
+    radii := make(map[int]SpellRadius)
+    for rows.Next() {
+        var r SpellRadius
+        if err := rows.Scan(&r.ID, &r.Radius, &r.PerLevel, &r.Max); err != nil {
+            return nil, err
+        }
+        radii[r.ID] = r
+    }
+    if err := rows.Err(); err != nil {
+        return nil, err
+    }
+
+    ctx.SpellRadii = radii
+    return radii, nil
+}
+
+// GetSpellForEdit reads the editable columns of a rank spell
+func GetSpellForEdit(ctx *AppContext, id int) (*SpellEdit, error) {
+    rows, err := queryWithDebug(ctx.DB, `
+        SELECT id, spell_name_enus, spell_desc_enus, spell_tooltip_enus, spell_icon_id,
+            effect_base_points_1, effect_base_points_2, effect_base_points_3
+        FROM Spell
+        WHERE id = ?`, id)
+    if err != nil {
+        return nil, err
+    }
+    defer rows.Close()
+
+    if !rows.Next() {
+        if err := rows.Err(); err != nil {
+            return nil, err
+        }
+        return nil, fmt.Errorf("spell %d does not exist", id)
+    }
+    var e SpellEdit
+    var name, desc, tooltip sql.NullString
+    if err := rows.Scan(&e.ID, &name, &desc, &tooltip, &e.IconID,
+        &e.BasePoints[0], &e.BasePoints[1], &e.BasePoints[2]); err != nil {
+        return nil, err
+    }
+    e.Name, e.Desc, e.Tooltip = name.String, desc.String, tooltip.String
+    return &e, nil
+}
+
+// spellTextColumns are the localized text columns of Spell
+var spellTextColumns = []string{"spell_name", "spell_desc", "spell_tooltip"}
+
+// GetSpellTexts loads the name, description and tooltip of spells in every client locale
+func GetSpellTexts(ctx *AppContext, ids []int) (map[int]map[string]SpellText, error) {
+    result := make(map[int]map[string]SpellText)
+    if len(ids) == 0 {
+        return result, nil
+    }
+
+    var columns []string
+    for _, l := range clientLocales {
+        for _, c := range spellTextColumns {
+            columns = append(columns, localeColumn(c, l))
+        }
+    }
+    placeholders := make([]string, len(ids))
+    args := make([]interface{}, len(ids))
+    for i, id := range ids {
+        placeholders[i] = "?"
+        args[i] = id
+    }
+    query := fmt.Sprintf("SELECT id, %s FROM Spell WHERE id IN (%s)",
+        strings.Join(columns, ", "), strings.Join(placeholders, ","))
+
+    rows, err := queryWithDebug(ctx.DB, query, args...)
+    if err != nil {
+        return nil, err
+    }
+    defer rows.Close()
+
+    for rows.Next() {
+        var id int
+        values := make([]sql.NullString, len(columns))
+        dest := []interface{}{&id}
+        for i := range values {
+            dest = append(dest, &values[i])
+        }
+        if err := rows.Scan(dest...); err != nil {
+            return nil, err
+        }
+        texts := make(map[string]SpellText, len(clientLocales))
+        for i, l := range clientLocales {
+            v := values[i*len(spellTextColumns):]
+            texts[l] = SpellText{Name: v[0].String, Desc: v[1].String, Tooltip: v[2].String}
+        }
+        result[id] = texts
+    }
+    return result, rows.Err()
+}
+
+// UpdateSpellTextsQuery writes the name, description and tooltip of a spell in every client locale
+func UpdateSpellTextsQuery(id int, texts map[string]SpellText) (string, []interface{}) {
+    var sets []string
+    var args []interface{}
+    for _, l := range clientLocales {
+        t := texts[l]
+        for i, value := range []string{t.Name, t.Desc, t.Tooltip} {
+            sets = append(sets, localeColumn(spellTextColumns[i], l)+" = ?")
+            args = append(args, value)
+        }
+    }
+    args = append(args, id)
+    return fmt.Sprintf("UPDATE Spell SET %s WHERE id = ?", strings.Join(sets, ", ")), args
+}
+
+// UpdateSpellQuery writes the editable columns of a spell
+func UpdateSpellQuery(e *SpellEdit) (string, []interface{}) {
+    query := `UPDATE Spell SET
+        spell_name_enus = ?, spell_desc_enus = ?, spell_tooltip_enus = ?, spell_icon_id = ?,
+        effect_base_points_1 = ?, effect_base_points_2 = ?, effect_base_points_3 = ?
+        WHERE id = ?`
+    args := []interface{}{
+        e.Name, e.Desc, e.Tooltip, e.IconID,
+        e.BasePoints[0], e.BasePoints[1], e.BasePoints[2],
+        e.ID,
+    }
+    return query, args
+}
+
 // Talent queries
-func GetTalentsForSpec(ctx *AppContext, specID int) ([]Talent, []int, error) {
-    query := `
-        SELECT id, spec_id, tier_id, column_index,
+const talentColumns = `id, spec_id, tier_id, column_index,
                rank_1, rank_2, rank_3, rank_4, rank_5, rank_6, rank_7, rank_8, rank_9,
                pre_req_talent_1, pre_req_talent_2, pre_req_talent_3,
                pre_req_rank_1, pre_req_rank_2, pre_req_rank_3,
-               flags, req_spell_id, allow_for_pet_flags_1, allow_for_pet_flags_2
+               flags, req_spell_id, allow_for_pet_flags_1, allow_for_pet_flags_2`
+
+// GetTalentsForSpec returns the talents of a tab and the spell IDs of all their ranks
+func GetTalentsForSpec(ctx *AppContext, specID int) ([]Talent, []int, error) {
+    query := `
+        SELECT ` + talentColumns + `
         FROM Talent
         WHERE spec_id = ?`
 
@@ -125,9 +423,32 @@ func GetTalentsForSpec(ctx *AppContext, specID int) ([]Talent, []int, error) {
     }
     defer rows.Close()
 
-    var talents []Talent
-    spellIDMap := make(map[int]struct{})
+    talents, err := scanTalents(rows)
+    if err != nil {
+        return nil, nil, err
+    }
+
+    return talents, allRankSpellIDs(talents), nil
+}
 
+func GetAllTalents(ctx *AppContext) ([]Talent, error) {
+    query := `
+        SELECT ` + talentColumns + `
+        FROM Talent
+        ORDER BY id`
+
+    rows, err := queryWithDebug(ctx.DB, query)
+    if err != nil {
+        return nil, err
+    }
+    defer rows.Close()
+
+    return scanTalents(rows)
+}
+
+// scanTalents reads Talent rows selected with talentColumns
+func scanTalents(rows *sql.Rows) ([]Talent, error) {
+    var talents []Talent
     for rows.Next() {
         var t Talent
         var tier, col sql.NullInt64
@@ -140,14 +461,26 @@ func GetTalentsForSpec(ctx *AppContext, specID int) ([]Talent, []int, error) {
             &t.PreReqRank[0], &t.PreReqRank[1], &t.PreReqRank[2],
             &t.Flags, &t.ReqSpellID, &t.AllowForPetFlags1, &t.AllowForPetFlags2,
         ); err != nil {
-            return nil, nil, err
+            return nil, err
         }
 
         t.TierID = tier
         t.ColumnIndex = col
         talents = append(talents, t)
+    }
+
+    if err := rows.Err(); err != nil {
+        return nil, err
+    }
+
+    return talents, nil
+}
 
-        // Collect first-rank spell IDs
+// firstRankSpellIDs collects the unique first-rank spell IDs of the given talents, the spells
+// shown as icons and names
+func firstRankSpellIDs(talents []Talent) []int {
+    spellIDMap := make(map[int]struct{})
+    for _, t := range talents {
         if t.Rank[0].Valid {
             spellIDMap[int(t.Rank[0].Int64)] = struct{}{}
         }
@@ -159,7 +492,22 @@ func GetTalentsForSpec(ctx *AppContext, specID int) ([]Talent, []int, error) {
         spellIDs = append(spellIDs, id)
     }
 
-    return talents, spellIDs, nil
+    return spellIDs
+}
+
+// allRankSpellIDs collects the unique spell IDs of every rank of the given talents
+func allRankSpellIDs(talents []Talent) []int {
+    seen := make(map[int]bool)
+    var spellIDs []int
+    for _, t := range talents {
+        for _, r := range t.Rank {
+            if r.Valid && r.Int64 > 0 && !seen[int(r.Int64)] {
+                seen[int(r.Int64)] = true
+                spellIDs = append(spellIDs, int(r.Int64))
+            }
+        }
+    }
+    return spellIDs
 }
 
 // SpellIcon queries
@@ -197,6 +545,37 @@ func GetAllSpellIcons(ctx *AppContext) (map[int]string, error) {
     return icons, nil
 }
 
+// GetSpellIconUsage counts the spells and talent tabs using each SpellIcon ID
+func GetSpellIconUsage(ctx *AppContext) (map[int]int, error) {
+    usage := make(map[int]int)
+    for _, query := range []string{
+        "SELECT spell_icon_id, COUNT(*) FROM Spell GROUP BY spell_icon_id",
+        "SELECT spell_icon, COUNT(*) FROM TalentTab GROUP BY spell_icon",
+    } {
+        rows, err := queryWithDebug(ctx.DB, query)
+        if err != nil {
+            return nil, err
+        }
+        for rows.Next() {
+            var id sql.NullInt64
+            var count int
+            if err := rows.Scan(&id, &count); err != nil {
+                rows.Close()
+                return nil, err
+            }
+            if id.Valid {
+                usage[int(id.Int64)] += count
+            }
+        }
+        err = rows.Err()
+        rows.Close()
+        if err != nil {
+            return nil, err
+        }
+    }
+    return usage, nil
+}
+
 // Classes queries
 func GetAllClasses(ctx *AppContext) (map[int]ChrClass, error) {
     rows, err := queryWithDebug(ctx.DB, "SELECT id, name_enus, pet_name_token FROM ChrClasses")
@@ -222,6 +601,44 @@ func GetAllClasses(ctx *AppContext) (map[int]ChrClass, error) {
     return classes, nil
 }
 
+// GetAllCreatureFamilies reads the CreatureFamily table, used to name pet talent bits.
+// The table is optional: a failed read is cached as no families and not retried.
+func GetAllCreatureFamilies(ctx *AppContext) (map[int]CreatureFamily, error) {
+    if ctx.CreatureFamilies != nil {
+        return ctx.CreatureFamilies, nil
+    }
+
+    families, err := queryCreatureFamilies(ctx)
+    if err != nil {
+        ctx.CreatureFamilies = make(map[int]CreatureFamily)
+        return nil, err
+    }
+    ctx.CreatureFamilies = families
+    return families, nil
+}
+
+func queryCreatureFamilies(ctx *AppContext) (map[int]CreatureFamily, error) {
+    rows, err := queryWithDebug(ctx.DB, "SELECT id, name_enus, category_enum_id, pet_talent_type FROM CreatureFamily")
+    if err != nil {
+        return nil, err
+    }
+    defer rows.Close()
+
+    families := make(map[int]CreatureFamily)
+    for rows.Next() {
+        var f CreatureFamily
+        var name sql.NullString
+        if err := rows.Scan(&f.ID, &name, &f.CategoryEnumID, &f.PetTalentType); err != nil {
+            return nil, err
+        }
+        f.NameENUS = name.String
+        families[f.ID] = f
+    }
+    if err := rows.Err(); err != nil {
+        return nil, err
+    }
+    return families, nil
+}
 
 // Insert/Update/Delete Talent
 func InsertTalentQuery(t *Talent) (string, []interface{}) {
@@ -272,8 +689,13 @@ func DeleteTalentQuery(id int) (string, []interface{}) {
     return "DELETE FROM Talent WHERE id = ?", []interface{}{id}
 }
 
+// sqlQueryer is implemented by *sql.DB and *sql.Tx
+type sqlQueryer interface {
+    Query(query string, args ...interface{}) (*sql.Rows, error)
+}
+
 // Query with automatic error logging
-func queryWithDebug(db *sql.DB, query string, args ...interface{}) (*sql.Rows, error) {
+func queryWithDebug(db sqlQueryer, query string, args ...interface{}) (*sql.Rows, error) {
     rows, err := db.Query(query, args...)
     if err != nil {
         fmt.Printf("[SQL Error]\nQuery: %s\nArgs: %v\nError: %v\n", query, args, err)
@@ -281,8 +703,16 @@ func queryWithDebug(db *sql.DB, query string, args ...interface{}) (*sql.Rows, e
     return rows, err
 }
 
-// Exec with automatic error logging
-func execWithDebug(db *sql.DB, query string, args ...interface{}) (sql.Result, error) {
+// sqlExecer is implemented by *sql.DB, *sql.Tx and the dry-run SQLPatch
+type sqlExecer interface {
+    Exec(query string, args ...interface{}) (sql.Result, error)
+}
+
+// Exec with automatic error logging. Writes are refused in read-only mode.
+func execWithDebug(ctx *AppContext, db sqlExecer, query string, args ...interface{}) (sql.Result, error) {
+    if ctx.ReadOnly {
+        return nil, errReadOnly
+    }
     res, err := db.Exec(query, args...)
     if err != nil {
         fmt.Printf("[SQL Exec Error]\nQuery: %s\nArgs: %v\nError: %v\n", query, args, err)
@@ -295,4 +725,22 @@ func nullInt64ToInterface(n sql.NullInt64) interface{} {
         return n.Int64
     }
     return nil
-}
\ No newline at end of file
+}
+
+// isIDConflict reports whether err is a MySQL duplicate key error (1062) or deadlock (1213).
+// Two editors inserting the same allocated ID get one of them: the gap locks taken by
+// SELECT ... FOR UPDATE over missing rows do not block each other, so both inserts wait
+// on the other's lock and MySQL rolls one transaction back.
+func isIDConflict(err error) bool {
+    var mysqlErr *mysql.MySQLError
+    return errors.As(err, &mysqlErr) && (mysqlErr.Number == 1062 || mysqlErr.Number == 1213)
+}
+
+// idInUseError explains an ID conflict of an insert with an allocated ID, other errors
+// are returned unchanged
+func idInUseError(err error, what string, id int) error {
+    if isIDConflict(err) {
+        return fmt.Errorf("%s ID %d is already in use, another editor may have created one at the same time. Please try again", what, id)
+    }
+    return err
+}
@@ -0,0 +1,34 @@
+// Copyright (c) 2025 TalentEditor
+//
+// TalentEditor is licensed under the MIT License.
+// See the LICENSE file for details.
+
+package main
+
+import (
+    "strings"
+    "testing"
+)
+
+func TestCloneTabQuery(t *testing.T) {
+    query, args := CloneTabQuery(500, CloneTabOptions{Name: "Arcane Copy", ClassMask: 128, OrderIndex: 3})
+    stmt, err := renderSQL(query, args)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    // Every locale gets the new name, none keeps the name of the source tab
+    for _, l := range clientLocales {
+        if want := localeColumn("name", l) + " = 'Arcane Copy'"; !strings.Contains(stmt, want) {
+            t.Errorf("clone query lacks %q:\n%s", want, stmt)
+        }
+    }
+    for _, want := range []string{"UPDATE talent_tab_clone SET id = 500", "class_mask = 128", "order_index = 3"} {
+        if !strings.Contains(stmt, want) {
+            t.Errorf("clone query lacks %q:\n%s", want, stmt)
+        }
+    }
+    if strings.Contains(stmt, "WHERE") {
+        t.Errorf("clone query must update the single copied row:\n%s", stmt)
+    }
+}
@@ -0,0 +1,203 @@
+// Copyright (c) 2025 TalentEditor
+//
+// TalentEditor is licensed under the MIT License.
+// See the LICENSE file for details.
+
+package main
+
+import (
+    "database/sql"
+    "fmt"
+    "strconv"
+    "strings"
+
+    "fyne.io/fyne/v2"
+    "fyne.io/fyne/v2/container"
+    "fyne.io/fyne/v2/dialog"
+    "fyne.io/fyne/v2/widget"
+)
+
+// TalentDependent is a talent that lists another talent as one of its prerequisites
+type TalentDependent struct {
+    Talent Talent
+    Slots  []int // prerequisite indexes (0..2) pointing at the talent
+}
+
+// findDependents returns every talent, across all tabs, that requires the given talent
+func findDependents(all []Talent, talentID int) []TalentDependent {
+    var dependents []TalentDependent
+    for _, t := range all {
+        if t.ID == talentID {
+            continue
+        }
+        var slots []int
+        for i, p := range t.PreReqTalent {
+            if p.Valid && int(p.Int64) == talentID {
+                slots = append(slots, i)
+            }
+        }
+        if len(slots) > 0 {
+            dependents = append(dependents, TalentDependent{Talent: t, Slots: slots})
+        }
+    }
+    return dependents
+}
+
+// deleteTalentCascade deletes a talent and updates its dependents in one transaction.
+// A zero repointTo clears the prerequisites, otherwise they are pointed at that talent.
+func deleteTalentCascade(ctx *AppContext, talent *Talent, dependents []TalentDependent, repointTo int) error {
+    if talent == nil || talent.ID == 0 {
+        return fmt.Errorf("invalid talent")
+    }
+    return runWrite(ctx, func(w *WriteTx) error {
+        for _, d := range dependents {
+            t := d.Talent
+            repointPrereqs(&t, d.Slots, repointTo)
+            query, args := UpdateTalentQuery(&t)
+            if _, err := w.Exec(query, args...); err != nil {
+                return err
+            }
+            w.TrackTalent(t.ID, &t)
+        }
+
+        query, args := DeleteTalentQuery(talent.ID)
+        if _, err := w.Exec(query, args...); err != nil {
+            return err
+        }
+        w.TrackTalent(talent.ID, nil)
+        return nil
+    })
+}
+
+// repointPrereqs points the prerequisite slots at another talent, or clears them when
+// repointTo is zero. A slot is cleared instead when the talent would require itself or
+// already requires the new talent.
+func repointPrereqs(t *Talent, slots []int, repointTo int) {
+    hasPrereq := func(id int) bool {
+        for _, p := range t.PreReqTalent {
+            if p.Valid && int(p.Int64) == id {
+                return true
+            }
+        }
+        return false
+    }
+    for _, slot := range slots {
+        if repointTo == 0 || repointTo == t.ID || hasPrereq(repointTo) {
+            t.PreReqTalent[slot] = sql.NullInt64{Int64: 0, Valid: true}
+            t.PreReqRank[slot] = sql.NullInt64{Int64: 0, Valid: true}
+        } else {
+            t.PreReqTalent[slot] = sql.NullInt64{Int64: int64(repointTo), Valid: true}
+        }
+    }
+}
+
+// showDeleteWithDependents lists the dependents of a talent and lets the user clear or
+// re-point their prerequisites before the talent is deleted
+func showDeleteWithDependents(ctx *AppContext, talent *Talent, all []Talent, dependents []TalentDependent, reloadTab func()) {
+    tabs, err := GetAllTalentTabs(ctx)
+    if err != nil {
+        dialog.ShowError(err, ctx.Window)
+        return
+    }
+    var deps []Talent
+    for _, d := range dependents {
+        deps = append(deps, d.Talent)
+    }
+    deps = append(deps, *talent)
+    spells, err := GetSpellsByIDs(ctx, firstRankSpellIDs(deps))
+    if err != nil {
+        dialog.ShowError(err, ctx.Window)
+        return
+    }
+
+    var lines []string
+    for _, d := range dependents {
+        tabName := fmt.Sprintf("tab_%d", d.Talent.SpecID.Int64)
+        if tab, ok := tabs[int(d.Talent.SpecID.Int64)]; ok {
+            tabName = tab.NameENUS
+        }
+        slots := make([]string, len(d.Slots))
+        for i, s := range d.Slots {
+            slots[i] = strconv.Itoa(s + 1)
+        }
+        lines = append(lines, trf("%s (ID %d) in %s, pre-requisite %s",
+            talentDisplayName(&d.Talent, spells), d.Talent.ID, tabName, strings.Join(slots, ", ")))
+    }
+
+    actionClear := tr("Clear these prerequisites")
+    actionRepoint := tr("Re-point them to talent ID")
+    repointEntry := widget.NewEntry()
+    repointEntry.SetPlaceHolder(tr("talent ID"))
+    repointEntry.Disable()
+    action := widget.NewRadioGroup([]string{actionClear, actionRepoint}, func(selected string) {
+        if selected == actionRepoint {
+            repointEntry.Enable()
+        } else {
+            repointEntry.Disable()
+        }
+    })
+    action.SetSelected(actionClear)
+
+    list := widget.NewLabel(strings.Join(lines, "\n"))
+    scroll := container.NewVScroll(list)
+    scroll.SetMinSize(fyne.NewSize(500, 200))
+
+    content := container.NewVBox(
+        widget.NewLabel(trn(len(dependents), "%d talent requires %s (ID %d):", "%d talents require %s (ID %d):", talentDisplayName(talent, spells), talent.ID)),
+        scroll,
+        action,
+        repointEntry,
+    )
+
+    dialog.ShowCustomConfirm(tr("Delete Talent"), tr("Delete"), tr("Cancel"), content, func(ok bool) {
+        if !ok {
+            return
+        }
+
+        repointTo := 0
+        if action.Selected == actionRepoint {
+            id, err := strconv.Atoi(strings.TrimSpace(repointEntry.Text))
+            if err != nil {
+                dialog.ShowError(fmt.Errorf("invalid talent ID %q", repointEntry.Text), ctx.Window)
+                return
+            }
+            if err := validateRepointTarget(all, talent.ID, id, dependents); err != nil {
+                dialog.ShowError(err, ctx.Window)
+                return
+            }
+            repointTo = id
+        }
+
+        if err := deleteTalentCascade(ctx, talent, dependents, repointTo); err != nil {
+            dialog.ShowError(err, ctx.Window)
+            return
+        }
+        reloadTab()
+        resetEditorContainer(ctx)
+    }, ctx.Window)
+}
+
+// validateRepointTarget checks that the replacement prerequisite exists, is not the deleted talent
+// and is in the tab of every dependent, the talent frame only shows prerequisites within a tab.
+// A dependent may be the target, its own prerequisite is cleared instead.
+func validateRepointTarget(all []Talent, deletedID int, targetID int, dependents []TalentDependent) error {
+    if targetID == deletedID {
+        return fmt.Errorf("talent %d is the talent being deleted", targetID)
+    }
+    var target *Talent
+    for i := range all {
+        if all[i].ID == targetID {
+            target = &all[i]
+        }
+    }
+    if target == nil {
+        return fmt.Errorf("talent %d does not exist", targetID)
+    }
+    for _, d := range dependents {
+        if d.Talent.ID != targetID && d.Talent.SpecID.Int64 != target.SpecID.Int64 {
+            return fmt.Errorf("talent %d is in tab %d but its dependent %d is in tab %d, prerequisites must be in the same tab",
+                targetID, target.SpecID.Int64, d.Talent.ID, d.Talent.SpecID.Int64)
+        }
+    }
+    return nil
+}
@@ -0,0 +1,224 @@
+// Copyright (c) 2025 TalentEditor
+//
+// TalentEditor is licensed under the MIT License.
+// See the LICENSE file for details.
+
+package main
+
+import (
+    "database/sql"
+    "fmt"
+    "sort"
+
+    "fyne.io/fyne/v2"
+    "fyne.io/fyne/v2/dialog"
+)
+
+// GridCell is a tier/column position in a talent grid
+type GridCell struct {
+    Tier   int
+    Column int
+}
+
+// GridOp computes new positions for the talents of one tab. Only talents whose
+// position changes need to be returned.
+type GridOp func(talents []Talent) (map[int]GridCell, error)
+
+func cellOf(t *Talent) GridCell {
+    return GridCell{Tier: int(t.TierID.Int64), Column: int(t.ColumnIndex.Int64)}
+}
+
+// insertTierOp pushes every talent at or below tier down by one
+func insertTierOp(tier int) GridOp {
+    return func(talents []Talent) (map[int]GridCell, error) {
+        moves := make(map[int]GridCell)
+        for i := range talents {
+            c := cellOf(&talents[i])
+            if c.Tier >= tier {
+                moves[talents[i].ID] = GridCell{Tier: c.Tier + 1, Column: c.Column}
+            }
+        }
+        return moves, nil
+    }
+}
+
+// deleteTierOp removes an empty tier and pulls every talent below it up by one
+func deleteTierOp(tier int) GridOp {
+    return func(talents []Talent) (map[int]GridCell, error) {
+        moves := make(map[int]GridCell)
+        for i := range talents {
+            c := cellOf(&talents[i])
+            if c.Tier == tier {
+                return nil, fmt.Errorf("tier %d is not empty, talent %d is placed in it", tier+1, talents[i].ID)
+            }
+            if c.Tier > tier {
+                moves[talents[i].ID] = GridCell{Tier: c.Tier - 1, Column: c.Column}
+            }
+        }
+        return moves, nil
+    }
+}
+
+// shiftColumnOp moves every talent of a column one column left (-1) or right (+1)
+func shiftColumnOp(column, direction int) GridOp {
+    return func(talents []Talent) (map[int]GridCell, error) {
+        moves := make(map[int]GridCell)
+        for i := range talents {
+            c := cellOf(&talents[i])
+            if c.Column == column {
+                moves[talents[i].ID] = GridCell{Tier: c.Tier, Column: c.Column + direction}
+            }
+        }
+        return moves, nil
+    }
+}
+
+// swapCellsOp exchanges the talents of two cells, either cell may be empty
+func swapCellsOp(a, b GridCell) GridOp {
+    return func(talents []Talent) (map[int]GridCell, error) {
+        moves := make(map[int]GridCell)
+        for i := range talents {
+            switch cellOf(&talents[i]) {
+            case a:
+                moves[talents[i].ID] = b
+            case b:
+                moves[talents[i].ID] = a
+            }
+        }
+        return moves, nil
+    }
+}
+
+// mirrorOp mirrors the whole tab horizontally
+func mirrorOp() GridOp {
+    return func(talents []Talent) (map[int]GridCell, error) {
+        moves := make(map[int]GridCell)
+        for i := range talents {
+            c := cellOf(&talents[i])
+            mirrored := GridCell{Tier: c.Tier, Column: NUM_TALENT_COLUMNS - 1 - c.Column}
+            if mirrored != c {
+                moves[talents[i].ID] = mirrored
+            }
+        }
+        return moves, nil
+    }
+}
+
+// validateLayout checks the grid bounds and that no two talents share a cell after the moves
+func validateLayout(talents []Talent, moves map[int]GridCell) error {
+    occupied := make(map[GridCell]int)
+    ids := make([]int, 0, len(talents))
+    byID := make(map[int]*Talent, len(talents))
+    for i := range talents {
+        ids = append(ids, talents[i].ID)
+        byID[talents[i].ID] = &talents[i]
+    }
+    sort.Ints(ids)
+
+    for _, id := range ids {
+        c, moved := moves[id]
+        if !moved {
+            c = cellOf(byID[id])
+        }
+        if c.Tier < 0 || c.Tier >= MAX_NUM_TALENT_TIERS || c.Column < 0 || c.Column >= NUM_TALENT_COLUMNS {
+            return fmt.Errorf("talent %d would be moved outside the grid (tier %d, column %d)", id, c.Tier+1, c.Column+1)
+        }
+        if other, ok := occupied[c]; ok {
+            return fmt.Errorf("talents %d and %d would share tier %d, column %d", other, id, c.Tier+1, c.Column+1)
+        }
+        occupied[c] = id
+    }
+    return nil
+}
+
+// applyGridOp runs a grid operation on a tab and stores all moved positions in one transaction
+func applyGridOp(ctx *AppContext, tabID int, op GridOp) error {
+    all, err := loadAllTalents(ctx)
+    if err != nil {
+        return err
+    }
+    var talents []Talent
+    for _, t := range all {
+        if t.SpecID.Valid && int(t.SpecID.Int64) == tabID {
+            talents = append(talents, t)
+        }
+    }
+
+    moves, err := op(talents)
+    if err != nil {
+        return err
+    }
+    if len(moves) == 0 {
+        return nil
+    }
+    if err := validateLayout(talents, moves); err != nil {
+        return err
+    }
+
+    return runWrite(ctx, func(w *WriteTx) error {
+        for i := range talents {
+            t := talents[i]
+            c, moved := moves[t.ID]
+            if !moved {
+                continue
+            }
+            if _, err := w.Exec("UPDATE Talent SET tier_id = ?, column_index = ? WHERE id = ?", c.Tier, c.Column, t.ID); err != nil {
+                return err
+            }
+            t.TierID = sql.NullInt64{Int64: int64(c.Tier), Valid: true}
+            t.ColumnIndex = sql.NullInt64{Int64: int64(c.Column), Valid: true}
+            w.TrackTalent(t.ID, &t)
+        }
+        return nil
+    })
+}
+
+// gridOpsMenu builds the "Grid" submenu of a cell context menu
+func gridOpsMenu(ctx *AppContext, tab TalentTab, row, col int, reloadTab func()) *fyne.Menu {
+    run := func(op GridOp) func() {
+        return func() {
+            if err := applyGridOp(ctx, tab.ID, op); err != nil {
+                dialog.ShowError(err, ctx.Window)
+                return
+            }
+            ctx.SwapSource = nil
+            reloadTab()
+        }
+    }
+
+    // Tiers and columns are shown counted from 1 like in tooltips and patch notes
+    cell := GridCell{Tier: row, Column: col}
+    items := []*fyne.MenuItem{
+        fyne.NewMenuItem(trf("Insert Empty Tier At %d", row+1), run(insertTierOp(row))),
+        fyne.NewMenuItem(trf("Delete Empty Tier %d", row+1), run(deleteTierOp(row))),
+        fyne.NewMenuItem(trf("Shift Column %d Left", col+1), run(shiftColumnOp(col, -1))),
+        fyne.NewMenuItem(trf("Shift Column %d Right", col+1), run(shiftColumnOp(col, 1))),
+        fyne.NewMenuItemSeparator(),
+    }
+
+    if ctx.SwapSource != nil && ctx.SwapSource.TabID == tab.ID && ctx.SwapSource.Cell != cell {
+        src := ctx.SwapSource.Cell
+        items = append(items, fyne.NewMenuItem(
+            trf("Swap With Tier %d, Column %d", src.Tier+1, src.Column+1), run(swapCellsOp(src, cell))))
+    }
+    items = append(items,
+        fyne.NewMenuItem(tr("Pick Cell For Swap"), func() {
+            ctx.SwapSource = &SwapSource{TabID: tab.ID, Cell: cell}
+        }),
+        fyne.NewMenuItemSeparator(),
+        fyne.NewMenuItem(tr("Mirror Tab Horizontally"), run(mirrorOp())),
+    )
+
+    if ctx.ReadOnly {
+        for _, item := range items {
+            item.Disabled = true
+        }
+    }
+    return fyne.NewMenu(tr("Grid"), items...)
+}
+
+// SwapSource is the first cell picked for a swap
+type SwapSource struct {
+    TabID int
+    Cell  GridCell
+}
@@ -0,0 +1,378 @@
+// Copyright (c) 2025 TalentEditor
+//
+// TalentEditor is licensed under the MIT License.
+// See the LICENSE file for details.
+
+package main
+
+import (
+    "fmt"
+    "strings"
+
+    "fyne.io/fyne/v2/lang"
+)
+
+// uiLanguages are the languages of the user interface. English is the source language,
+// its strings are the keys of the other catalogs.
+var uiLanguages = []string{"en", "de"}
+
+// uiLanguage is the language of the user interface, set once at startup
+var uiLanguage = "en"
+
+// uiCatalogs maps the English UI strings to their translation per language. Strings
+// missing from a catalog are shown in English.
+var uiCatalogs = map[string]map[string]string{
+    "de": {
+        // Main window
+        "Talent Tabs":                      "Talentbäume",
+        "Editor Pane":                      "Editor",
+        "Talent Grid":                      "Talentraster",
+        "Filter classes, tabs, talents":    "Klassen, Bäume, Talente filtern",
+        "Select a TalentTab from the left": "Links einen Talentbaum auswählen",
+        "Select a talent cell to edit":     "Ein Talentfeld zum Bearbeiten auswählen",
+        "READ ONLY":                        "NUR LESEN",
+        "DRY RUN: %d pending":              "PROBELAUF: %d ausstehend",
+        "Empty talent slot":                "Leeres Talentfeld",
+        "read-only":                        "nur lesen",
+
+        // Talent editor
+        "Talent ID":                  "Talent-ID",
+        "Spec ID":                    "Baum-ID",
+        "Tier ID":                    "Reihe",
+        "Column Index":               "Spalte",
+        "Rank %d":                    "Rang %d",
+        "Spell...":                   "Zauber...",
+        "Pre-requisite Talent ID %d": "Voraussetzung Talent-ID %d",
+        "Pre-requisite Rank %d":      "Voraussetzung Rang %d",
+        "Flags":                      "Flags",
+        "Required Spell ID":          "Benötigte Zauber-ID",
+        "Allow for Pet Flags 1":      "Begleiter-Flags 1",
+        "Allow for Pet Flags 2":      "Begleiter-Flags 2",
+        "Save":                       "Speichern",
+        "Cancel":                     "Abbrechen",
+        "Delete":                     "Löschen",
+        "Rank Wizard...":             "Rang-Assistent...",
+        "Confirm Delete":             "Löschen bestätigen",
+        "Are you sure you want to delete this talent?": "Soll dieses Talent wirklich gelöscht werden?",
+
+        // Cell menu
+        "Copy":                           "Kopieren",
+        "Cut":                            "Ausschneiden",
+        "Copy %d Talents":                "%d Talente kopieren",
+        "Cut %d Talents":                 "%d Talente ausschneiden",
+        "Duplicate To Tab...":            "In Baum duplizieren...",
+        "Duplicate %d Talents To Tab...": "%d Talente in Baum duplizieren...",
+        "Renumber %d Talents...":         "%d Talente neu nummerieren...",
+        "Paste":                          "Einfügen",
+        "Paste %d Talent":                "%d Talent einfügen",
+        "Paste %d Talents":               "%d Talente einfügen",
+        "Grid":                           "Raster",
+        "Copy All Talents In Tab":        "Alle Talente des Baums kopieren",
+
+        // Paste errors
+        "talent %d would be placed outside the grid (tier %d, column %d)": "Talent %d läge außerhalb des Rasters (Reihe %d, Spalte %d)",
+        "tier %d, column %d is already taken by talent %d":                "Reihe %d, Spalte %d ist bereits durch Talent %d belegt",
+
+        // Compare menu
+        "Compare":                           "Vergleichen",
+        "Save Snapshot...":                  "Snapshot speichern...",
+        "Compare Tab With Compare Database": "Baum mit Vergleichsdatenbank vergleichen",
+        "Compare Tab With Snapshot...":      "Baum mit Snapshot vergleichen...",
+        "Patch Notes From Compare Database": "Patchnotes aus Vergleichsdatenbank",
+        "Patch Notes From Snapshot...":      "Patchnotes aus Snapshot...",
+
+        // Dry run menu
+        "Dry Run":                          "Probelauf",
+        "Dry-Run Mode":                     "Probelauf-Modus",
+        "Save SQL Patch...":                "SQL-Patch speichern...",
+        "Patch Notes From Pending Changes": "Patchnotes aus ausstehenden Änderungen",
+        "Discard Pending Changes":          "Ausstehende Änderungen verwerfen",
+        "Apply SQL Patch File...":          "SQL-Patchdatei anwenden...",
+
+        // Dry run dialogs
+        "Disable Dry-Run Mode": "Probelauf-Modus beenden",
+        "Discard %d pending statements? Save the SQL patch first to keep them.":     "%d ausstehende Anweisungen verwerfen? Zum Behalten zuerst den SQL-Patch speichern.",
+        "Discard %d pending statements?":                                            "%d ausstehende Anweisungen verwerfen?",
+        "Save SQL Patch":                                                            "SQL-Patch speichern",
+        "There are no pending changes. Enable dry-run mode and edit talents first.": "Es gibt keine ausstehenden Änderungen. Zuerst den Probelauf-Modus aktivieren und Talente bearbeiten.",
+        "Patch Notes":                          "Patchnotes",
+        "There are no pending talent changes.": "Es gibt keine ausstehenden Talentänderungen.",
+        "Apply SQL Patch":                      "SQL-Patch anwenden",
+        "Execute %d statements from %s against %s?\nAll statements run in one transaction and are rolled back on the first error.": "%d Anweisungen aus %s auf %s ausführen?\nAlle Anweisungen laufen in einer Transaktion und werden beim ersten Fehler zurückgerollt.",
+        "Applied %d statements.": "%d Anweisungen angewendet.",
+
+        // Tools menu
+        "Tools":                        "Werkzeuge",
+        "Search Talents...":            "Talente suchen...",
+        "Clone Current Tab...":         "Aktuellen Baum klonen...",
+        "Renumber Talents...":          "Talente neu nummerieren...",
+        "Class Mask Of Current Tab...": "Klassenmaske des aktuellen Baums...",
+        "Class Tab Assignments":        "Baumzuordnung der Klassen",
+        "Icon Of Current Tab...":       "Symbol des aktuellen Baums...",
+        "Names Of Current Tab...":      "Namen des aktuellen Baums...",
+        "Missing Translations":         "Fehlende Übersetzungen",
+        "Icon Gallery":                 "Symbolgalerie",
+        "Missing Icon Report":          "Bericht fehlender Symbole",
+
+        // Bitfields
+        "Add to spellbook": "Zum Zauberbuch hinzufügen",
+        "Bit %d":           "Bit %d",
+        "(none)":           "(keine)",
+        "invalid number":   "ungültige Zahl",
+        "Bits...":          "Bits...",
+        "OK":               "OK",
+
+        // Class mask
+        "Class Mask": "Klassenmaske",
+        "Select a TalentTab from the left first.": "Zuerst links einen Talentbaum auswählen.",
+        "%d: (no ChrClasses row)":                 "%d: (keine ChrClasses-Zeile)",
+        "bit %d (class %d)":                       "Bit %d (Klasse %d)",
+        "Warning: %s match no ChrClasses row.":    "Warnung: %s passen zu keiner ChrClasses-Zeile.",
+        "Class Mask - %s":                         "Klassenmaske - %s",
+        "Close":                                   "Schließen",
+        "The tab list shows the new class mask once the SQL patch has been applied.": "Die Baumliste zeigt die neue Klassenmaske, sobald der SQL-Patch angewendet wurde.",
+        "Tabs per class:": "Bäume je Klasse:",
+        "  %s (%d): class IDs %s have no ChrClasses row": "  %s (%d): Klassen-IDs %s haben keine ChrClasses-Zeile",
+        "  %s (%d): class mask %d matches no class":      "  %s (%d): Klassenmaske %d passt zu keiner Klasse",
+        "Tabs shared by several classes (%d):":           "Von mehreren Klassen geteilte Bäume (%d):",
+        "Class mask warnings (%d):":                      "Klassenmasken-Warnungen (%d):",
+
+        // Grid menu
+        "Insert Empty Tier At %d":      "Leere Reihe bei %d einfügen",
+        "Delete Empty Tier %d":         "Leere Reihe %d löschen",
+        "Shift Column %d Left":         "Spalte %d nach links schieben",
+        "Shift Column %d Right":        "Spalte %d nach rechts schieben",
+        "Swap With Tier %d, Column %d": "Mit Reihe %d, Spalte %d tauschen",
+        "Pick Cell For Swap":           "Feld zum Tauschen wählen",
+        "Mirror Tab Horizontally":      "Baum horizontal spiegeln",
+
+        // Bulk editor
+        "%d talent selected\nIDs: %s":  "%d Talent ausgewählt\nIDs: %s",
+        "%d talents selected\nIDs: %s": "%d Talente ausgewählt\nIDs: %s",
+        "mixed":                        "gemischt",
+        "(move to tab)":                "(in Baum verschieben)",
+        "Apply":                        "Übernehmen",
+        "Move":                         "Verschieben",
+        "Clear Selection":              "Auswahl aufheben",
+
+        // Tooltip
+        "Rank %d/%d":                       "Rang %d/%d",
+        "Requires %d point in %s Talents":  "Benötigt %d Punkt in %s-Talenten",
+        "Requires %d points in %s Talents": "Benötigt %d Punkte in %s-Talenten",
+        "Talent %d":                        "Talent %d",
+        "Requires %d point in %s":          "Benötigt %d Punkt in %s",
+        "Requires %d points in %s":         "Benötigt %d Punkte in %s",
+        "(spell %d not found)":             "(Zauber %d nicht gefunden)",
+        "Next rank:":                       "Nächster Rang:",
+        "Talent ID %d":                     "Talent-ID %d",
+        "Show next rank":                   "Nächsten Rang zeigen",
+        "Tooltip rank":                     "Tooltip-Rang",
+
+        // Search
+        "name %q":           "Name %q",
+        "description":       "Beschreibung",
+        "talent ID":         "Talent-ID",
+        "rank %d spell":     "Zauber von Rang %d",
+        "rank %d spell %s":  "Zauber von Rang %d, %s",
+        "required spell":    "benötigter Zauber",
+        "required spell %s": "benötigter Zauber, %s",
+        "pre-requisite %d":  "Voraussetzung %d",
+        "Search Talents":    "Talente suchen",
+        "Search by talent ID, spell ID, spell name or description": "Nach Talent-ID, Zauber-ID, Zaubername oder Beschreibung suchen",
+        "%s (ID %d) in %s, tier %d, column %d - %s":                "%s (ID %d) in %s, Reihe %d, Spalte %d - %s",
+        "e.g. 1234, 12345 or Improved Fireball":                    "z. B. 1234, 12345 oder Verbesserter Feuerball",
+        "%d talent found":                                          "%d Talent gefunden",
+        "%d talents found":                                         "%d Talente gefunden",
+        "Search":                                                   "Suchen",
+
+        // Duplicate and delete dialogs
+        "Pet":                                "Begleiter",
+        "Target tab":                         "Zielbaum",
+        "Duplicate To Tab":                   "In Baum duplizieren",
+        "Duplicate":                          "Duplizieren",
+        "%s (ID %d) in %s, pre-requisite %s": "%s (ID %d) in %s, Voraussetzung %s",
+        "Clear these prerequisites":          "Diese Voraussetzungen entfernen",
+        "Re-point them to talent ID":         "Auf Talent-ID umleiten",
+        "%d talent requires %s (ID %d):":     "%d Talent benötigt %s (ID %d):",
+        "%d talents require %s (ID %d):":     "%d Talente benötigen %s (ID %d):",
+        "Delete Talent":                      "Talent löschen",
+
+        // Spell editor
+        "Spell Editor":                 "Zaubereditor",
+        "Enter a rank spell ID first.": "Zuerst eine Rang-Zauber-ID eingeben.",
+        "All Locales...":               "Alle Sprachen...",
+        "Pick...":                      "Auswählen...",
+        "Icon - %s":                    "Symbol - %s",
+        "(effect data unavailable)":    "(Effektdaten nicht verfügbar)",
+        "Name":                         "Name",
+        "Description":                  "Beschreibung",
+        "Preview":                      "Vorschau",
+        "Tooltip":                      "Tooltip",
+        "Icon ID":                      "Symbol-ID",
+        "Effect %d base points":        "Basispunkte Effekt %d",
+        "Spell %d - %s":                "Zauber %d - %s",
+
+        // Rank wizard
+        "Linear":         "Linear",
+        "Custom list":    "Eigene Liste",
+        "effect %d = %s": "Effekt %d = %s",
+        "Rank %d: %s":    "Rang %d: %s",
+        "Effect %d":      "Effekt %d",
+        "added per rank, empty or 0 for the template value": "pro Rang addiert, leer oder 0 für den Wert der Vorlage",
+        "e.g. 1, 2, 3, 4, 5":                               "z. B. 1, 2, 3, 4, 5",
+        "e.g. v * r or (v + 2) * r - 2":                    "z. B. v * r oder (v + 2) * r - 2",
+        "Rewrite plain numbers in description and tooltip": "Einfache Zahlen in Beschreibung und Tooltip anpassen",
+        "Template spell ID":                                "Zauber-ID der Vorlage",
+        "Ranks":                                            "Ränge",
+        "Scale":                                            "Skalieren",
+        "Mode":                                             "Modus",
+        "Step":                                             "Schritt",
+        "Values":                                           "Werte",
+        "Formula":                                          "Formel",
+        "Rank Wizard - Talent %d":                          "Rang-Assistent - Talent %d",
+        "Generate %d rank spell from %d - %s":              "%d Rang-Zauber aus %d - %s erzeugen",
+        "Generate %d rank spells from %d - %s":             "%d Rang-Zauber aus %d - %s erzeugen",
+        "Generate":                                         "Erzeugen",
+
+        // Icon gallery
+        "no SpellIcon row":                         "keine SpellIcon-Zeile",
+        "SpellIcon %s, used by %d":                 "SpellIcon %s, von %d verwendet",
+        ", no texture file":                        ", keine Texturdatei",
+        "%d of %d icons":                           "%d von %d Symbolen",
+        "ID %d, used by %d":                        "ID %d, von %d verwendet",
+        "Filter by name or SpellIcon ID":           "Nach Name oder SpellIcon-ID filtern",
+        "Use Icon":                                 "Symbol verwenden",
+        "Create SpellIcon":                         "SpellIcon erstellen",
+        "%s has no SpellIcon row yet. Create one?": "%s hat noch keine SpellIcon-Zeile. Eine erstellen?",
+        "Tab Icon":                                 "Baumsymbol",
+        "Tab Icon - %s":                            "Baumsymbol - %s",
+        "The tab list shows the new icon once the SQL patch has been applied.": "Die Baumliste zeigt das neue Symbol, sobald der SQL-Patch angewendet wurde.",
+
+        // Missing icon report
+        "tab %d":                                     "Baum %d",
+        "%s (talent %d, %s, rank %d)":                "%s (Talent %d, %s, Rang %d)",
+        "All talent rank spells have an icon.":       "Alle Rang-Zauber der Talente haben ein Symbol.",
+        "%d rank spell without an icon:":             "%d Rang-Zauber ohne Symbol:",
+        "%d rank spells without an icon:":            "%d Rang-Zauber ohne Symbol:",
+        "%s (spell %d)":                              "%s (Zauber %d)",
+        "  icon %d has no SpellIcon row":             "  Symbol %d hat keine SpellIcon-Zeile",
+        "  icon %d: texture %s is in no icon source": "  Symbol %d: Textur %s ist in keiner Symbolquelle",
+        "  used by %s":                               "  verwendet von %s",
+        "  suggestions: %s":                          "  Vorschläge: %s",
+        "Missing Icons":                              "Fehlende Symbole",
+        "%d icon file could not be loaded, the embedded icon is shown instead:":    "%d Symboldatei konnte nicht geladen werden, stattdessen wird das eingebettete Symbol gezeigt:",
+        "%d icon files could not be loaded, the embedded icons are shown instead:": "%d Symboldateien konnten nicht geladen werden, stattdessen werden die eingebetteten Symbole gezeigt:",
+
+        // Translations
+        "Tab Names":      "Baumnamen",
+        "Tab Names - %s": "Baumnamen - %s",
+        "The tab list shows the new names once the SQL patch has been applied.": "Die Baumliste zeigt die neuen Namen, sobald der SQL-Patch angewendet wurde.",
+        "  tab name: %s":                         "  Baumname: %s",
+        "name":                                   "Name",
+        "tooltip":                                "Tooltip",
+        "  %s (talent %d) rank %d, spell %d: %s": "  %s (Talent %d) Rang %d, Zauber %d: %s",
+        "All tabs are translated to %s.":         "Alle Bäume sind in %s übersetzt.",
+        "%d tab with missing %s translations:":   "%d Baum mit fehlenden Übersetzungen in %s:",
+        "%d tabs with missing %s translations:":  "%d Bäume mit fehlenden Übersetzungen in %s:",
+        "Select the locales to check.":           "Die zu prüfenden Sprachen auswählen.",
+
+        // Compare view
+        "Old: %s":          "Alt: %s",
+        "New: %s":          "Neu: %s",
+        "Added":            "Hinzugefügt",
+        "Removed":          "Entfernt",
+        "Moved":            "Verschoben",
+        "Changed":          "Geändert",
+        "Compare - %s":     "Vergleich - %s",
+        "No differences.":  "Keine Unterschiede.",
+        "Save Markdown...": "Markdown speichern...",
+        "Save HTML...":     "HTML speichern...",
+
+        // Clone and renumber dialogs
+        "Info": "Info",
+        "Template config.json created at %s. Please edit it and restart.": "Vorlage config.json unter %s erstellt. Bitte bearbeiten und neu starten.",
+        "Clone Tab":        "Baum klonen",
+        "Keep rank spells": "Rang-Zauber behalten",
+        "Class mask":       "Klassenmaske",
+        "Order index":      "Reihenfolge",
+        "Clone Tab - %s":   "Baum klonen - %s",
+        "Clone":            "Klonen",
+        "Created tab %d.":  "Baum %d erstellt.",
+        "The new tab is listed once the SQL patch has been applied.": "Der neue Baum wird aufgeführt, sobald der SQL-Patch angewendet wurde.",
+        "Current tab":                          "Aktueller Baum",
+        "Class":                                "Klasse",
+        "Talent IDs":                           "Talent-IDs",
+        "e.g. 1001, 1005, 1010-1020":           "z. B. 1001, 1005, 1010-1020",
+        "first new talent ID":                  "erste neue Talent-ID",
+        "Talents":                              "Talente",
+        "IDs":                                  "IDs",
+        "New start ID":                         "Neue Start-ID",
+        "Renumber Talents":                     "Talente neu nummerieren",
+        "Renumber Talents - Preview":           "Talente neu nummerieren - Vorschau",
+        "Renumber %d talent:":                  "%d Talent neu nummerieren:",
+        "Renumber %d talents:":                 "%d Talente neu nummerieren:",
+        "Rewrite prerequisites of %d talent:":  "Voraussetzungen von %d Talent anpassen:",
+        "Rewrite prerequisites of %d talents:": "Voraussetzungen von %d Talenten anpassen:",
+        "  Talent %d, Pre-requisite Talent ID %d: %s → %s": "  Talent %d, Voraussetzung Talent-ID %d: %s → %s",
+
+        // Tab list
+        "Unknown":                "Unbekannt",
+        "Pets":                   "Begleiter",
+        "%s, %s, %s and %d more": "%s, %s, %s und %d weitere",
+    },
+}
+
+// tr returns the UI string in the UI language, the English string if it is not translated
+func tr(s string) string {
+    if t, ok := uiCatalogs[uiLanguage][s]; ok {
+        return t
+    }
+    return s
+}
+
+// trf formats a translated UI string
+func trf(format string, args ...any) string {
+    return fmt.Sprintf(tr(format), args...)
+}
+
+// trn formats the singular or plural form of a translated UI string for n, which is the
+// first format argument
+func trn(n int, singular, plural string, args ...any) string {
+    format := plural
+    if n == 1 {
+        format = singular
+    }
+    return trf(format, append([]any{n}, args...)...)
+}
+
+// uiLanguageOf returns the UI language of a locale like "de-DE" or "de_AT.UTF-8",
+// false when there is no catalog for it
+func uiLanguageOf(locale string) (string, bool) {
+    l := strings.ToLower(locale)
+    if i := strings.IndexAny(l, "-_."); i >= 0 {
+        l = l[:i]
+    }
+    for _, known := range uiLanguages {
+        if l == known {
+            return known, true
+        }
+    }
+    return "", false
+}
+
+// setUILanguage selects the configured UI language, or the language of the OS when none
+// is configured. Unknown OS languages fall back to English.
+func setUILanguage(configured string) error {
+    if configured != "" {
+        l, ok := uiLanguageOf(configured)
+        if !ok {
+            return fmt.Errorf("unknown UI language %q, use one of %s", configured, strings.Join(uiLanguages, ", "))
+        }
+        uiLanguage = l
+        return nil
+    }
+    if l, ok := uiLanguageOf(lang.SystemLocale().LanguageString()); ok {
+        uiLanguage = l
+    }
+    return nil
+}
@@ -0,0 +1,247 @@
+// Copyright (c) 2025 TalentEditor
+//
+// TalentEditor is licensed under the MIT License.
+// See the LICENSE file for details.
+
+package main
+
+import (
+    "sort"
+    "strings"
+    "sync"
+
+    "fyne.io/fyne/v2"
+    "fyne.io/fyne/v2/container"
+    "fyne.io/fyne/v2/dialog"
+    "fyne.io/fyne/v2/widget"
+)
+
+// iconCache holds the loaded icon resources by lower case texture name, nil for icons
+// found in no icon source, so every icon is read and decoded only once
+var iconCache = struct {
+    sync.Mutex
+    resources map[string]fyne.Resource
+}{resources: make(map[string]fyne.Resource)}
+
+// cachedIconResource returns the cached icon, loading it on first use
+func cachedIconResource(iconFile string, load func(string) fyne.Resource) fyne.Resource {
+    key := strings.ToLower(iconFile)
+    iconCache.Lock()
+    defer iconCache.Unlock()
+    if res, ok := iconCache.resources[key]; ok {
+        return res
+    }
+    res := load(iconFile)
+    iconCache.resources[key] = res
+    return res
+}
+
+// MissingIcon is a talent rank spell whose icon is in no icon source
+type MissingIcon struct {
+    Spell       Spell
+    Texture     string   // empty when the icon ID has no SpellIcon row
+    Talents     []string // the talents and ranks using the spell
+    Suggestions []string // similarly named textures
+}
+
+// findMissingIcons lists the rank spells of the talents whose icon has no SpellIcon row or
+// whose texture cannot be loaded, with texture suggestions by name similarity
+func findMissingIcons(talents []Talent, tabs map[int]TalentTab, spells map[int]Spell, iconIDs map[int]string,
+    hasTexture func(name string) bool, textures []string) []MissingIcon {
+    bySpell := make(map[int]*MissingIcon)
+    for i := range talents {
+        t := &talents[i]
+        tabName := trf("tab %d", t.SpecID.Int64)
+        if tab, ok := tabs[int(t.SpecID.Int64)]; ok {
+            tabName = tab.NameENUS
+        }
+
+        for r, rank := range t.Rank {
+            spell, ok := spells[int(rank.Int64)]
+            if !rank.Valid || rank.Int64 == 0 || !ok {
+                continue
+            }
+            texture, hasRow := iconIDs[int(spell.IconID.Int64)]
+            if hasRow && hasTexture(texture) {
+                continue
+            }
+
+            m, ok := bySpell[spell.ID]
+            if !ok {
+                // Without a texture name the spell name is the best hint
+                query := texture
+                if !hasRow {
+                    texture, query = "", spell.NameENUS
+                }
+                m = &MissingIcon{Spell: spell, Texture: texture, Suggestions: suggestIcons(query, textures, 3)}
+                bySpell[spell.ID] = m
+            }
+            m.Talents = append(m.Talents, trf("%s (talent %d, %s, rank %d)", talentDisplayName(t, spells), t.ID, tabName, r+1))
+        }
+    }
+
+    missing := make([]MissingIcon, 0, len(bySpell))
+    for _, m := range bySpell {
+        missing = append(missing, *m)
+    }
+    sort.Slice(missing, func(i, j int) bool { return missing[i].Spell.ID < missing[j].Spell.ID })
+    return missing
+}
+
+// suggestIcons returns the n textures most similar to the query
+func suggestIcons(query string, textures []string, n int) []string {
+    type scored struct {
+        name  string
+        score float64
+    }
+    var candidates []scored
+    for _, name := range textures {
+        if score := iconNameSimilarity(query, name); score > 0.3 {
+            candidates = append(candidates, scored{name, score})
+        }
+    }
+    sort.Slice(candidates, func(i, j int) bool {
+        if candidates[i].score != candidates[j].score {
+            return candidates[i].score > candidates[j].score
+        }
+        return candidates[i].name < candidates[j].name
+    })
+
+    var names []string
+    for i := 0; i < len(candidates) && i < n; i++ {
+        names = append(names, candidates[i].name)
+    }
+    return names
+}
+
+// iconNameSimilarity scores two names from 0 to 1, the better of the edit distance of the
+// normalized names and the share of the query's words found in the candidate
+func iconNameSimilarity(query, candidate string) float64 {
+    normalize := func(s string) string {
+        return strings.Map(func(r rune) rune {
+            if r >= 'a' && r <= 'z' || r >= '0' && r <= '9' {
+                return r
+            }
+            return -1
+        }, strings.ToLower(s))
+    }
+    q, c := normalize(query), normalize(candidate)
+    if q == "" || c == "" {
+        return 0
+    }
+
+    best := 1 - float64(levenshtein(q, c))/float64(max(len(q), len(c)))
+
+    words := strings.FieldsFunc(strings.ToLower(query), func(r rune) bool {
+        return !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9')
+    })
+    var total, found int
+    for _, w := range words {
+        if len(w) < 3 {
+            continue
+        }
+        total++
+        if strings.Contains(c, w) {
+            found++
+        }
+    }
+    if total > 0 {
+        best = max(best, float64(found)/float64(total))
+    }
+    return best
+}
+
+// levenshtein returns the edit distance of two ASCII strings
+func levenshtein(a, b string) int {
+    prev := make([]int, len(b)+1)
+    cur := make([]int, len(b)+1)
+    for j := range prev {
+        prev[j] = j
+    }
+    for i := 1; i <= len(a); i++ {
+        cur[0] = i
+        for j := 1; j <= len(b); j++ {
+            cost := 1
+            if a[i-1] == b[j-1] {
+                cost = 0
+            }
+            cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+        }
+        prev, cur = cur, prev
+    }
+    return prev[len(b)]
+}
+
+// missingIconReport prints the missing icons with the talents using them and the suggestions,
+// followed by the icon files of the search paths that could not be loaded
+func missingIconReport(missing []MissingIcon, failures map[string]error) string {
+    var sb strings.Builder
+    if len(missing) == 0 {
+        sb.WriteString(tr("All talent rank spells have an icon.") + "\n")
+    } else {
+        sb.WriteString(trn(len(missing), "%d rank spell without an icon:", "%d rank spells without an icon:") + "\n")
+    }
+    for _, m := range missing {
+        sb.WriteString("\n" + trf("%s (spell %d)", m.Spell.DisplayName(), m.Spell.ID) + "\n")
+        if m.Texture == "" {
+            sb.WriteString(trf("  icon %d has no SpellIcon row", m.Spell.IconID.Int64) + "\n")
+        } else {
+            sb.WriteString(trf("  icon %d: texture %s is in no icon source", m.Spell.IconID.Int64, m.Texture) + "\n")
+        }
+        for _, t := range m.Talents {
+            sb.WriteString(trf("  used by %s", t) + "\n")
+        }
+        if len(m.Suggestions) > 0 {
+            sb.WriteString(trf("  suggestions: %s", strings.Join(m.Suggestions, ", ")) + "\n")
+        }
+    }
+
+    if len(failures) > 0 {
+        files := make([]string, 0, len(failures))
+        for file := range failures {
+            files = append(files, file)
+        }
+        sort.Strings(files)
+        sb.WriteString("\n" + trn(len(files), "%d icon file could not be loaded, the embedded icon is shown instead:",
+            "%d icon files could not be loaded, the embedded icons are shown instead:") + "\n")
+        for _, file := range files {
+            sb.WriteString("  " + failures[file].Error() + "\n")
+        }
+    }
+    return sb.String()
+}
+
+// showMissingIcons opens the missing icon report
+func showMissingIcons(ctx *AppContext) {
+    talents, err := loadAllTalents(ctx)
+    if err != nil {
+        dialog.ShowError(err, ctx.Window)
+        return
+    }
+    spells, err := GetSpellsByIDs(ctx, allRankSpellIDs(talents))
+    if err != nil {
+        dialog.ShowError(err, ctx.Window)
+        return
+    }
+    tabs, err := GetAllTalentTabs(ctx)
+    if err != nil {
+        dialog.ShowError(err, ctx.Window)
+        return
+    }
+    iconIDs, err := GetAllSpellIcons(ctx)
+    if err != nil {
+        dialog.ShowError(err, ctx.Window)
+        return
+    }
+
+    hasTexture := func(name string) bool { return iconResourceByName(name) != nil }
+    missing := findMissingIcons(talents, tabs, spells, iconIDs, hasTexture, iconTextureNames())
+
+    report := widget.NewLabel(missingIconReport(missing, externalIconFailures()))
+    report.TextStyle = fyne.TextStyle{Monospace: true}
+
+    w := fyne.CurrentApp().NewWindow(tr("Missing Icons"))
+    w.SetContent(container.NewVScroll(report))
+    w.Resize(fyne.NewSize(800, 600))
+    w.Show()
+}
@@ -0,0 +1,345 @@
+// Copyright (c) 2025 TalentEditor
+//
+// TalentEditor is licensed under the MIT License.
+// See the LICENSE file for details.
+
+package main
+
+import (
+    "database/sql"
+    "fmt"
+    "image/color"
+    "path"
+    "path/filepath"
+    "sort"
+    "strconv"
+    "strings"
+
+    "fyne.io/fyne/v2"
+    "fyne.io/fyne/v2/canvas"
+    "fyne.io/fyne/v2/container"
+    "fyne.io/fyne/v2/dialog"
+    "fyne.io/fyne/v2/theme"
+    "fyne.io/fyne/v2/widget"
+)
+
+const (
+    galleryIconSize  = 48
+    galleryCellWidth = 140
+)
+
+// IconEntry is an icon of the gallery: a texture file, SpellIcon rows, or both
+type IconEntry struct {
+    Name     string // texture name without path and extension
+    IconIDs  []int  // SpellIcon rows using the texture
+    Texture  bool   // the texture is in a search path or in icons.zip
+    UsedBy   int    // spells and talent tabs using any of the IconIDs
+}
+
+// iconTextureNames returns the names of all textures in the search paths and icons.zip,
+// without extension and without duplicates
+func iconTextureNames() []string {
+    names := make([]string, 0, len(externalIcons)+len(iconLookup))
+    for _, file := range externalIcons {
+        names = append(names, strings.TrimSuffix(filepath.Base(file), filepath.Ext(file)))
+    }
+    for _, actual := range iconLookup {
+        name := strings.TrimSuffix(path.Base(actual), path.Ext(actual))
+        if _, ok := externalIcons[strings.ToLower(name)]; !ok {
+            names = append(names, name)
+        }
+    }
+    return names
+}
+
+// buildIconEntries merges the textures and the SpellIcon rows by texture name, sorted by name
+func buildIconEntries(iconIDs map[int]string, textures []string, usage map[int]int) []IconEntry {
+    byName := make(map[string]*IconEntry)
+    entry := func(name string) *IconEntry {
+        key := strings.ToLower(name)
+        e, ok := byName[key]
+        if !ok {
+            e = &IconEntry{Name: name}
+            byName[key] = e
+        }
+        return e
+    }
+
+    for _, name := range textures {
+        e := entry(name)
+        if !e.Texture {
+            e.Name = name // the file name has the real casing
+        }
+        e.Texture = true
+    }
+    for id, name := range iconIDs {
+        e := entry(name)
+        e.IconIDs = append(e.IconIDs, id)
+        e.UsedBy += usage[id]
+    }
+
+    entries := make([]IconEntry, 0, len(byName))
+    for _, e := range byName {
+        sort.Ints(e.IconIDs)
+        entries = append(entries, *e)
+    }
+    sort.Slice(entries, func(i, j int) bool {
+        return strings.ToLower(entries[i].Name) < strings.ToLower(entries[j].Name)
+    })
+    return entries
+}
+
+// filterIconEntries keeps the icons whose name contains the query, or with the queried SpellIcon ID
+func filterIconEntries(entries []IconEntry, query string) []IconEntry {
+    query = strings.ToLower(strings.TrimSpace(query))
+    if query == "" {
+        return entries
+    }
+    id, err := strconv.Atoi(query)
+    numeric := err == nil
+
+    var result []IconEntry
+    for _, e := range entries {
+        match := strings.Contains(strings.ToLower(e.Name), query)
+        if !match && numeric {
+            for _, iconID := range e.IconIDs {
+                if iconID == id {
+                    match = true
+                    break
+                }
+            }
+        }
+        if match {
+            result = append(result, e)
+        }
+    }
+    return result
+}
+
+// iconEntryInfo describes the SpellIcon rows, usage and texture of an icon
+func iconEntryInfo(e IconEntry) string {
+    info := tr("no SpellIcon row")
+    if len(e.IconIDs) > 0 {
+        ids := make([]string, len(e.IconIDs))
+        for i, id := range e.IconIDs {
+            ids[i] = strconv.Itoa(id)
+        }
+        info = trf("SpellIcon %s, used by %d", strings.Join(ids, ", "), e.UsedBy)
+    }
+    if !e.Texture {
+        info += tr(", no texture file")
+    }
+    return info
+}
+
+// createSpellIcon adds a SpellIcon row for a texture and returns its ID
+func createSpellIcon(ctx *AppContext, name string) (int, error) {
+    var id int
+    err := runWrite(ctx, func(w *WriteTx) error {
+        var maxID int64
+        if err := w.QueryRow("SELECT COALESCE(MAX(id), 0) FROM SpellIcon FOR UPDATE").Scan(&maxID); err != nil {
+            return err
+        }
+        if pending := w.MaxPendingID("SpellIcon"); pending > maxID {
+            maxID = pending
+        }
+        id = int(maxID + 1)
+        if _, err := w.Exec("INSERT INTO SpellIcon (id, name) VALUES (?, ?)", id, `Interface\Icons\`+name); err != nil {
+            return idInUseError(err, "SpellIcon", id)
+        }
+        w.TrackID("SpellIcon", id)
+        return nil
+    })
+    if err != nil {
+        return 0, err
+    }
+
+    // Pending rows are cached as well so pickers and previews resolve them
+    if icons, err := GetAllSpellIcons(ctx); err == nil {
+        icons[id] = name
+    }
+    return id, nil
+}
+
+// iconGallery is a filterable, virtualized grid of all icons
+type iconGallery struct {
+    Content  fyne.CanvasObject
+    Selected *IconEntry // nil until an icon is selected
+    grid     *widget.GridWrap
+    entries  []IconEntry
+    shown    []IconEntry
+}
+
+// newIconGallery loads the icons and builds the gallery
+func newIconGallery(ctx *AppContext) (*iconGallery, error) {
+    iconIDs, err := GetAllSpellIcons(ctx)
+    if err != nil {
+        return nil, err
+    }
+    usage, err := GetSpellIconUsage(ctx)
+    if err != nil {
+        return nil, err
+    }
+
+    g := &iconGallery{entries: buildIconEntries(iconIDs, iconTextureNames(), usage)}
+    g.shown = g.entries
+
+    details := widget.NewLabel("")
+    details.Truncation = fyne.TextTruncateEllipsis
+    status := widget.NewLabel("")
+    showStatus := func() {
+        status.SetText(trf("%d of %d icons", len(g.shown), len(g.entries)))
+    }
+
+    g.grid = widget.NewGridWrap(
+        func() int { return len(g.shown) },
+        func() fyne.CanvasObject {
+            img := canvas.NewImageFromResource(nil)
+            img.FillMode = canvas.ImageFillContain
+            img.SetMinSize(fyne.NewSize(galleryIconSize, galleryIconSize))
+            name := widget.NewLabel("")
+            name.Alignment = fyne.TextAlignCenter
+            name.Truncation = fyne.TextTruncateEllipsis
+            info := widget.NewLabel("")
+            info.Alignment = fyne.TextAlignCenter
+            info.Truncation = fyne.TextTruncateEllipsis
+            info.SizeName = theme.SizeNameCaptionText
+            width := canvas.NewRectangle(color.Transparent)
+            width.SetMinSize(fyne.NewSize(galleryCellWidth, 0))
+            return container.NewStack(width, container.NewVBox(img, name, info))
+        },
+        func(i widget.GridWrapItemID, o fyne.CanvasObject) {
+            e := g.shown[i]
+            box := o.(*fyne.Container).Objects[1].(*fyne.Container)
+            img := box.Objects[0].(*canvas.Image)
+            img.Resource = iconResourceByName(e.Name)
+            if img.Resource == nil {
+                img.Resource = theme.BrokenImageIcon()
+            }
+            img.Refresh()
+            box.Objects[1].(*widget.Label).SetText(e.Name)
+            info := tr("no SpellIcon row")
+            if len(e.IconIDs) > 0 {
+                info = trf("ID %d, used by %d", e.IconIDs[0], e.UsedBy)
+            }
+            box.Objects[2].(*widget.Label).SetText(info)
+        },
+    )
+    g.grid.OnSelected = func(i widget.GridWrapItemID) {
+        e := g.shown[i]
+        g.Selected = &e
+        details.SetText(fmt.Sprintf("%s - %s", e.Name, iconEntryInfo(e)))
+    }
+
+    filter := widget.NewEntry()
+    filter.SetPlaceHolder(tr("Filter by name or SpellIcon ID"))
+    filter.OnChanged = func(query string) {
+        g.shown = filterIconEntries(g.entries, query)
+        g.Selected = nil
+        g.grid.UnselectAll()
+        details.SetText("")
+        showStatus()
+        g.grid.Refresh()
+        g.grid.ScrollToTop()
+    }
+    showStatus()
+
+    g.Content = container.NewBorder(
+        container.NewBorder(nil, nil, nil, status, filter),
+        details, nil, nil, g.grid,
+    )
+    return g, nil
+}
+
+// selectIconID selects and scrolls to the icon with a SpellIcon ID, if it is shown
+func (g *iconGallery) selectIconID(id int) {
+    for i, e := range g.shown {
+        for _, iconID := range e.IconIDs {
+            if iconID == id {
+                g.grid.Select(i)
+                g.grid.ScrollTo(i)
+                return
+            }
+        }
+    }
+}
+
+// showIconGallery opens the gallery in its own window to browse the icons
+func showIconGallery(ctx *AppContext) {
+    g, err := newIconGallery(ctx)
+    if err != nil {
+        dialog.ShowError(err, ctx.Window)
+        return
+    }
+    w := fyne.CurrentApp().NewWindow(tr("Icon Gallery"))
+    w.SetContent(g.Content)
+    w.Resize(fyne.NewSize(900, 650))
+    w.Show()
+}
+
+// showIconPicker lets the user pick an icon and passes its SpellIcon ID to onPicked.
+// Picking a texture without a SpellIcon row offers to create one.
+func showIconPicker(ctx *AppContext, title string, current int, onPicked func(iconID int)) {
+    g, err := newIconGallery(ctx)
+    if err != nil {
+        dialog.ShowError(err, ctx.Window)
+        return
+    }
+
+    d := dialog.NewCustomConfirm(title, tr("Use Icon"), tr("Cancel"), g.Content, func(ok bool) {
+        if !ok || g.Selected == nil {
+            return
+        }
+        e := *g.Selected
+        if len(e.IconIDs) > 0 {
+            onPicked(e.IconIDs[0])
+            return
+        }
+        dialog.ShowConfirm(tr("Create SpellIcon"),
+            trf("%s has no SpellIcon row yet. Create one?", e.Name), func(ok bool) {
+                if !ok {
+                    return
+                }
+                id, err := createSpellIcon(ctx, e.Name)
+                if err != nil {
+                    dialog.ShowError(err, ctx.Window)
+                    return
+                }
+                onPicked(id)
+            }, ctx.Window)
+    }, ctx.Window)
+    d.Resize(fyne.NewSize(900, 650))
+    d.Show()
+    g.selectIconID(current)
+}
+
+// updateTabIcon stores a new icon for a tab
+func updateTabIcon(ctx *AppContext, tabID, iconID int) error {
+    return runWrite(ctx, func(w *WriteTx) error {
+        _, err := w.Exec("UPDATE TalentTab SET spell_icon = ? WHERE id = ?", iconID, tabID)
+        return err
+    })
+}
+
+// showTabIconPicker picks a new icon for the current tab
+func showTabIconPicker(ctx *AppContext) {
+    if ctx.CurrentTab == nil {
+        dialog.ShowInformation(tr("Tab Icon"), tr("Select a TalentTab from the left first."), ctx.Window)
+        return
+    }
+    tab := *ctx.CurrentTab
+    showIconPicker(ctx, trf("Tab Icon - %s", tab.NameENUS), int(tab.SpellIcon.Int64), func(iconID int) {
+        if err := updateTabIcon(ctx, tab.ID, iconID); err != nil {
+            dialog.ShowError(err, ctx.Window)
+            return
+        }
+        if ctx.Patch != nil {
+            dialog.ShowInformation(tr("Tab Icon"), tr("The tab list shows the new icon once the SQL patch has been applied."), ctx.Window)
+            return
+        }
+        if ctx.CurrentTab != nil && ctx.CurrentTab.ID == tab.ID {
+            ctx.CurrentTab.SpellIcon = sql.NullInt64{Int64: int64(iconID), Valid: true}
+        }
+        reloadTabs(ctx)
+    })
+}
@@ -0,0 +1,110 @@
+// Copyright (c) 2025 TalentEditor
+//
+// TalentEditor is licensed under the MIT License.
+// See the LICENSE file for details.
+
+package main
+
+import (
+    "bytes"
+    "fmt"
+    "image/png"
+    "os"
+    "path/filepath"
+    "strings"
+
+    "fyne.io/fyne/v2"
+)
+
+// externalIcons maps lower case texture names to icon files found in the configured
+// search paths. They take precedence over the embedded icons.zip.
+var externalIcons map[string]string
+
+// externalIconErrors holds the icon files of the search paths that could not be loaded,
+// guarded by the icon cache lock
+var externalIconErrors = make(map[string]error)
+
+// indexIconSearchPaths lists the .blp and .png files of the search paths. Earlier paths
+// win, and within a path a PNG wins over a BLP of the same name.
+func indexIconSearchPaths(paths []string) (map[string]string, error) {
+    index := make(map[string]string)
+    var failed []string
+    for _, dir := range paths {
+        entries, err := os.ReadDir(dir)
+        if err != nil {
+            failed = append(failed, dir)
+            continue
+        }
+        found := make(map[string]string)
+        for _, e := range entries {
+            ext := strings.ToLower(filepath.Ext(e.Name()))
+            if e.IsDir() || (ext != ".blp" && ext != ".png") {
+                continue
+            }
+            key := strings.ToLower(strings.TrimSuffix(e.Name(), filepath.Ext(e.Name())))
+            if existing, ok := found[key]; ok && strings.EqualFold(filepath.Ext(existing), ".png") {
+                continue
+            }
+            found[key] = filepath.Join(dir, e.Name())
+        }
+        for key, file := range found {
+            if _, ok := index[key]; !ok {
+                index[key] = file
+            }
+        }
+    }
+    if len(failed) > 0 {
+        return index, fmt.Errorf("icon search paths not readable: %s", strings.Join(failed, ", "))
+    }
+    return index, nil
+}
+
+// loadIconFile loads a PNG icon, or decodes a BLP icon and converts it to PNG
+func loadIconFile(file string) (fyne.Resource, error) {
+    data, err := os.ReadFile(file)
+    if err != nil {
+        return nil, err
+    }
+    name := filepath.Base(file)
+    if !strings.EqualFold(filepath.Ext(file), ".blp") {
+        return fyne.NewStaticResource(name, data), nil
+    }
+
+    img, err := decodeBLP(data)
+    if err != nil {
+        return nil, fmt.Errorf("%s: %w", file, err)
+    }
+    var buf bytes.Buffer
+    if err := png.Encode(&buf, img); err != nil {
+        return nil, err
+    }
+    return fyne.NewStaticResource(strings.TrimSuffix(name, filepath.Ext(name))+".png", buf.Bytes()), nil
+}
+
+// externalIconResource loads an icon from the search paths, nil if there is none or it
+// cannot be decoded so the embedded icon is used instead. Load errors are logged and
+// listed by the missing icon report, the embedded icon may differ from the one in game.
+func externalIconResource(iconFile string) fyne.Resource {
+    file, ok := externalIcons[strings.ToLower(iconFile)]
+    if !ok {
+        return nil
+    }
+    res, err := loadIconFile(file)
+    if err != nil {
+        fmt.Printf("[Icon Error]\nFile: %s\nError: %v\n", file, err)
+        externalIconErrors[file] = err
+        return nil
+    }
+    return res
+}
+
+// externalIconFailures returns the icon files of the search paths that failed to load so far
+func externalIconFailures() map[string]error {
+    iconCache.Lock()
+    defer iconCache.Unlock()
+    failures := make(map[string]error, len(externalIconErrors))
+    for file, err := range externalIconErrors {
+        failures[file] = err
+    }
+    return failures
+}
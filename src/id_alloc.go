@@ -0,0 +1,153 @@
+// Copyright (c) 2025 TalentEditor
+//
+// TalentEditor is licensed under the MIT License.
+// See the LICENSE file for details.
+
+package main
+
+import (
+    "fmt"
+    "math"
+    "os"
+    "os/user"
+)
+
+// Talent ID allocation strategies, see IDAllocationConfig
+const (
+    AllocAtomic    = "atomic"     // MAX(id)+1, locked inside the insert transaction
+    AllocRange     = "range"      // like atomic, but only inside the reserved range of the profile or user
+    AllocLowestGap = "lowest_gap" // lowest free ID, inside the reserved range when one is configured
+)
+
+// idBounds returns the inclusive ID range new talents may use. A range on the
+// connection profile wins over a range reserved for the current user.
+func idBounds(ctx *AppContext) (IDRange, bool, error) {
+    bounds := IDRange{Min: 1, Max: math.MaxInt32}
+    if ctx.Config == nil {
+        return bounds, false, nil
+    }
+
+    var reserved *IDRange
+    if ctx.Config.DBC.IDRange != nil {
+        reserved = ctx.Config.DBC.IDRange
+    } else if r, ok := ctx.Config.IDAllocation.Ranges[allocationUser(ctx.Config)]; ok {
+        reserved = &r
+    }
+
+    if reserved == nil {
+        return bounds, false, nil
+    }
+    if reserved.Min < 1 || reserved.Max < reserved.Min {
+        return bounds, false, fmt.Errorf("invalid talent ID range %d-%d in config.json", reserved.Min, reserved.Max)
+    }
+    return *reserved, true, nil
+}
+
+// allocationUser is the user name used to look up the reserved ID range
+func allocationUser(cfg *Config) string {
+    if cfg.IDAllocation.User != "" {
+        return cfg.IDAllocation.User
+    }
+    if u, err := user.Current(); err == nil && u.Username != "" {
+        return u.Username
+    }
+    if name := os.Getenv("USERNAME"); name != "" {
+        return name
+    }
+    return os.Getenv("USER")
+}
+
+// allocateTalentIDs picks n unused talent IDs inside the write transaction using the
+// configured strategy. Concurrent editors can still pick the same IDs: the locks taken
+// over missing rows do not block each other, so the insert of one editor fails. Callers
+// report that with idInUseError instead of preventing it.
+func allocateTalentIDs(ctx *AppContext, w *WriteTx, n int) ([]int, error) {
+    if n <= 0 {
+        return nil, nil
+    }
+
+    strategy := AllocAtomic
+    if ctx.Config != nil && ctx.Config.IDAllocation.Strategy != "" {
+        strategy = ctx.Config.IDAllocation.Strategy
+    }
+
+    bounds, reserved, err := idBounds(ctx)
+    if err != nil {
+        return nil, err
+    }
+    if strategy == AllocAtomic {
+        bounds = IDRange{Min: 1, Max: math.MaxInt32}
+    }
+
+    var ids []int
+    switch strategy {
+    case AllocAtomic, AllocRange:
+        if strategy == AllocRange && !reserved {
+            return nil, fmt.Errorf("ID allocation strategy %q needs an \"id_range\" on the connection or a range for user %q",
+                strategy, allocationUser(ctx.Config))
+        }
+
+        var maxID int64
+        err := w.QueryRow("SELECT COALESCE(MAX(id), 0) FROM Talent WHERE id BETWEEN ? AND ? FOR UPDATE",
+            bounds.Min, bounds.Max).Scan(&maxID)
+        if err != nil {
+            return nil, err
+        }
+        if pending := w.MaxPendingTalentID(); pending > maxID && pending <= int64(bounds.Max) {
+            maxID = pending
+        }
+
+        next := int(maxID) + 1
+        if next < bounds.Min {
+            next = bounds.Min
+        }
+        for i := 0; i < n; i++ {
+            ids = append(ids, next+i)
+        }
+
+    case AllocLowestGap:
+        used, err := usedTalentIDs(w, bounds)
+        if err != nil {
+            return nil, err
+        }
+        for id := bounds.Min; id <= bounds.Max && len(ids) < n; id++ {
+            if !used[id] {
+                ids = append(ids, id)
+            }
+        }
+
+    default:
+        return nil, fmt.Errorf("unknown ID allocation strategy %q in config.json", strategy)
+    }
+
+    if len(ids) < n || ids[len(ids)-1] > bounds.Max {
+        return nil, fmt.Errorf("no free talent IDs left in range %d-%d", bounds.Min, bounds.Max)
+    }
+    return ids, nil
+}
+
+// usedTalentIDs returns the talent IDs inside the bounds, including pending dry-run inserts
+func usedTalentIDs(w *WriteTx, bounds IDRange) (map[int]bool, error) {
+    rows, err := w.Query("SELECT id FROM Talent WHERE id BETWEEN ? AND ? FOR UPDATE", bounds.Min, bounds.Max)
+    if err != nil {
+        return nil, err
+    }
+    defer rows.Close()
+
+    used := make(map[int]bool)
+    for rows.Next() {
+        var id int
+        if err := rows.Scan(&id); err != nil {
+            return nil, err
+        }
+        used[id] = true
+    }
+    if err := rows.Err(); err != nil {
+        return nil, err
+    }
+
+    for _, id := range w.PendingTalentIDs() {
+        used[id] = true
+    }
+    return used, nil
+}
@@ -0,0 +1,353 @@
+// Copyright (c) 2025 TalentEditor
+//
+// TalentEditor is licensed under the MIT License.
+// See the LICENSE file for details.
+
+package main
+
+import (
+    "fmt"
+    "sort"
+    "strings"
+
+    "fyne.io/fyne/v2"
+    "fyne.io/fyne/v2/container"
+    "fyne.io/fyne/v2/dialog"
+    "fyne.io/fyne/v2/widget"
+)
+
+// clientLocales are the 3.3.5 client locales with their own DBC text columns, e.g. name_dede
+var clientLocales = []string{"enUS", "koKR", "frFR", "deDE", "zhCN", "zhTW", "esES", "esMX", "ruRU"}
+
+const defaultLocale = "enUS"
+
+// SpellText is the name, description and tooltip of a spell in one locale
+type SpellText struct {
+    Name    string
+    Desc    string
+    Tooltip string
+}
+
+// localeColumn returns the column of a localized text, e.g. spell_name_dede
+func localeColumn(base, locale string) string {
+    return base + "_" + strings.ToLower(locale)
+}
+
+// isClientLocale reports whether the locale has DBC text columns
+func isClientLocale(locale string) bool {
+    for _, l := range clientLocales {
+        if l == locale {
+            return true
+        }
+    }
+    return false
+}
+
+// tabName returns the name of a tab in a locale, the enUS name when it is not translated
+func tabName(t TalentTab, locale string) string {
+    if name, ok := t.OtherLanguage[locale]; ok && name.String != "" {
+        return name.String
+    }
+    return t.NameENUS
+}
+
+// tabNames returns the name of a tab in every client locale
+func tabNames(t TalentTab) map[string]string {
+    names := map[string]string{defaultLocale: t.NameENUS}
+    for l, name := range t.OtherLanguage {
+        names[l] = name.String
+    }
+    return names
+}
+
+// newLocaleSelect switches the display locale of tab names and spell texts
+func newLocaleSelect(ctx *AppContext) *widget.Select {
+    sel := widget.NewSelect(clientLocales, nil)
+    sel.SetSelected(ctx.Locale)
+    sel.OnChanged = func(locale string) {
+        ctx.Locale = locale
+        // Cached spells hold the texts of the previous locale
+        invalidateSpellCaches(ctx)
+        reloadTabs(ctx)
+        reloadCurrentTab(ctx)
+    }
+    return sel
+}
+
+// newTranslationGrid lays out one row per client locale below a header, with the given
+// columns of entries side by side
+func newTranslationGrid(headers []string, entries map[string][]*widget.Entry) fyne.CanvasObject {
+    grid := container.NewGridWithColumns(len(headers) + 1)
+    grid.Add(widget.NewLabel(""))
+    for _, h := range headers {
+        grid.Add(widget.NewLabelWithStyle(h, fyne.TextAlignLeading, fyne.TextStyle{Bold: true}))
+    }
+    for _, l := range clientLocales {
+        grid.Add(widget.NewLabel(l))
+        for _, e := range entries[l] {
+            grid.Add(e)
+        }
+    }
+    return grid
+}
+
+// showTabTranslations edits the name of the current tab in every client locale
+func showTabTranslations(ctx *AppContext) {
+    if ctx.CurrentTab == nil {
+        dialog.ShowInformation(tr("Tab Names"), tr("Select a TalentTab from the left first."), ctx.Window)
+        return
+    }
+    tab := *ctx.CurrentTab
+    names := tabNames(tab)
+
+    entries := make(map[string][]*widget.Entry)
+    for _, l := range clientLocales {
+        e := widget.NewEntry()
+        e.SetText(names[l])
+        if ctx.ReadOnly {
+            e.Disable()
+        }
+        entries[l] = []*widget.Entry{e}
+    }
+    content := container.NewVScroll(newTranslationGrid([]string{tr("Name")}, entries))
+    content.SetMinSize(fyne.NewSize(450, 400))
+
+    title := trf("Tab Names - %s", tab.NameENUS)
+    if ctx.ReadOnly {
+        dialog.ShowCustom(title, tr("Close"), content, ctx.Window)
+        return
+    }
+    dialog.ShowCustomConfirm(title, tr("Save"), tr("Cancel"), content, func(ok bool) {
+        if !ok {
+            return
+        }
+        updated := make(map[string]string)
+        for l, e := range entries {
+            updated[l] = strings.TrimSpace(e[0].Text)
+        }
+        err := runWrite(ctx, func(w *WriteTx) error {
+            query, args := UpdateTabNamesQuery(tab.ID, updated)
+            _, err := w.Exec(query, args...)
+            return err
+        })
+        if err != nil {
+            dialog.ShowError(err, ctx.Window)
+            return
+        }
+        if ctx.Patch != nil {
+            dialog.ShowInformation(tr("Tab Names"), tr("The tab list shows the new names once the SQL patch has been applied."), ctx.Window)
+            return
+        }
+        reloadTabs(ctx)
+    }, ctx.Window)
+}
+
+// showSpellTranslations edits the name, description and tooltip of a spell in every client locale.
+// onSaved receives the saved texts by locale.
+func showSpellTranslations(ctx *AppContext, spellID int, onSaved func(texts map[string]SpellText)) {
+    all, err := GetSpellTexts(ctx, []int{spellID})
+    if err != nil {
+        dialog.ShowError(err, ctx.Window)
+        return
+    }
+    texts, ok := all[spellID]
+    if !ok {
+        dialog.ShowError(fmt.Errorf("spell %d does not exist", spellID), ctx.Window)
+        return
+    }
+
+    entries := make(map[string][]*widget.Entry)
+    for _, l := range clientLocales {
+        t := texts[l]
+        var row []*widget.Entry
+        for i, value := range []string{t.Name, t.Desc, t.Tooltip} {
+            e := widget.NewEntry()
+            if i > 0 {
+                e = widget.NewMultiLineEntry()
+                e.Wrapping = fyne.TextWrapWord
+                e.SetMinRowsVisible(2)
+            }
+            e.SetText(value)
+            if ctx.ReadOnly {
+                e.Disable()
+            }
+            row = append(row, e)
+        }
+        entries[l] = row
+    }
+    content := container.NewVScroll(newTranslationGrid([]string{tr("Name"), tr("Description"), tr("Tooltip")}, entries))
+    content.SetMinSize(fyne.NewSize(1000, 600))
+
+    title := trf("Spell %d - %s", spellID, texts[defaultLocale].Name)
+    if ctx.ReadOnly {
+        dialog.ShowCustom(title, tr("Close"), content, ctx.Window)
+        return
+    }
+    dialog.ShowCustomConfirm(title, tr("Save"), tr("Cancel"), content, func(ok bool) {
+        if !ok {
+            return
+        }
+        updated := make(map[string]SpellText)
+        for l, row := range entries {
+            updated[l] = SpellText{Name: row[0].Text, Desc: row[1].Text, Tooltip: row[2].Text}
+        }
+        err := runWrite(ctx, func(w *WriteTx) error {
+            query, args := UpdateSpellTextsQuery(spellID, updated)
+            _, err := w.Exec(query, args...)
+            return err
+        })
+        if err != nil {
+            dialog.ShowError(err, ctx.Window)
+            return
+        }
+        delete(ctx.Spells, spellID)
+        if onSaved != nil {
+            onSaved(updated)
+        }
+    }, ctx.Window)
+}
+
+// missingTranslationsReport lists per tab the tab names and rank spell texts that have an
+// enUS text but none in one of the checked locales
+func missingTranslationsReport(tabs map[int]TalentTab, talents []Talent, texts map[int]map[string]SpellText, locales []string) string {
+    if len(locales) == 0 {
+        return tr("Select the locales to check.")
+    }
+
+    // missingIn lists the locales without a text for an enUS text
+    missingIn := func(enUS string, text func(locale string) string) []string {
+        var missing []string
+        if strings.TrimSpace(enUS) == "" {
+            return nil
+        }
+        for _, l := range locales {
+            if strings.TrimSpace(text(l)) == "" {
+                missing = append(missing, l)
+            }
+        }
+        return missing
+    }
+
+    byTab := make(map[int][]Talent)
+    for _, t := range talents {
+        byTab[int(t.SpecID.Int64)] = append(byTab[int(t.SpecID.Int64)], t)
+    }
+    tabIDs := make([]int, 0, len(tabs))
+    for id := range tabs {
+        tabIDs = append(tabIDs, id)
+    }
+    sort.Ints(tabIDs)
+
+    var sb strings.Builder
+    incomplete := 0
+    for _, id := range tabIDs {
+        tab := tabs[id]
+        var lines []string
+        names := tabNames(tab)
+        if missing := missingIn(tab.NameENUS, func(l string) string { return names[l] }); len(missing) > 0 {
+            lines = append(lines, trf("  tab name: %s", strings.Join(missing, ", ")))
+        }
+
+        tabTalents := byTab[id]
+        sort.Slice(tabTalents, func(i, j int) bool {
+            a, b := tabTalents[i], tabTalents[j]
+            if a.TierID.Int64 != b.TierID.Int64 {
+                return a.TierID.Int64 < b.TierID.Int64
+            }
+            return a.ColumnIndex.Int64 < b.ColumnIndex.Int64
+        })
+        for _, t := range tabTalents {
+            for r, rank := range t.Rank {
+                spellTexts, ok := texts[int(rank.Int64)]
+                if !rank.Valid || rank.Int64 == 0 || !ok {
+                    continue
+                }
+                enUS := spellTexts[defaultLocale]
+                var parts []string
+                fields := []struct {
+                    label string
+                    value func(SpellText) string
+                }{
+                    {"name", func(s SpellText) string { return s.Name }},
+                    {"description", func(s SpellText) string { return s.Desc }},
+                    {"tooltip", func(s SpellText) string { return s.Tooltip }},
+                }
+                for _, f := range fields {
+                    missing := missingIn(f.value(enUS), func(l string) string { return f.value(spellTexts[l]) })
+                    if len(missing) > 0 {
+                        parts = append(parts, tr(f.label)+" "+strings.Join(missing, ", "))
+                    }
+                }
+                if len(parts) > 0 {
+                    lines = append(lines, trf("  %s (talent %d) rank %d, spell %d: %s",
+                        enUS.Name, t.ID, r+1, rank.Int64, strings.Join(parts, "; ")))
+                }
+            }
+        }
+
+        if len(lines) > 0 {
+            incomplete++
+            fmt.Fprintf(&sb, "\n%s (%d)\n%s\n", tab.NameENUS, tab.ID, strings.Join(lines, "\n"))
+        }
+    }
+
+    if incomplete == 0 {
+        return trf("All tabs are translated to %s.", strings.Join(locales, ", "))
+    }
+    return trn(incomplete, "%d tab with missing %s translations:", "%d tabs with missing %s translations:", strings.Join(locales, ", ")) + "\n" + sb.String()
+}
+
+// showMissingTranslations opens the missing translations report for the checked locales,
+// by default the required locales of the config
+func showMissingTranslations(ctx *AppContext) {
+    tabs, err := GetAllTalentTabs(ctx)
+    if err != nil {
+        dialog.ShowError(err, ctx.Window)
+        return
+    }
+    talents, err := loadAllTalents(ctx)
+    if err != nil {
+        dialog.ShowError(err, ctx.Window)
+        return
+    }
+    texts, err := GetSpellTexts(ctx, allRankSpellIDs(talents))
+    if err != nil {
+        dialog.ShowError(err, ctx.Window)
+        return
+    }
+
+    required := make(map[string]bool)
+    for _, l := range ctx.Config.Locale.Required {
+        required[l] = true
+    }
+
+    report := widget.NewLabel("")
+    report.TextStyle = fyne.TextStyle{Monospace: true}
+    checks := make(map[string]*widget.Check)
+    update := func() {
+        var locales []string
+        for _, l := range clientLocales {
+            if c := checks[l]; c != nil && c.Checked {
+                locales = append(locales, l)
+            }
+        }
+        report.SetText(missingTranslationsReport(tabs, talents, texts, locales))
+    }
+
+    checkRow := container.NewHBox()
+    for _, l := range clientLocales[1:] {
+        c := widget.NewCheck(l, nil)
+        c.SetChecked(required[l] || len(required) == 0)
+        checks[l] = c
+        checkRow.Add(c)
+    }
+    for _, c := range checks {
+        c.OnChanged = func(bool) { update() }
+    }
+    update()
+
+    w := fyne.CurrentApp().NewWindow(tr("Missing Translations"))
+    w.SetContent(container.NewBorder(checkRow, nil, nil, nil, container.NewVScroll(report)))
+    w.Resize(fyne.NewSize(900, 600))
+    w.Show()
+}
@@ -0,0 +1,286 @@
+// Copyright (c) 2025 TalentEditor
+//
+// TalentEditor is licensed under the MIT License.
+// See the LICENSE file for details.
+
+package main
+
+import (
+    "fmt"
+    "io"
+
+    "fyne.io/fyne/v2"
+    "fyne.io/fyne/v2/dialog"
+)
+
+// buildMainMenu constructs the window menu bar
+func buildMainMenu(ctx *AppContext) *fyne.MainMenu {
+    compareMenu := fyne.NewMenu(tr("Compare"),
+        fyne.NewMenuItem(tr("Save Snapshot..."), func() { saveSnapshotHandler(ctx) }),
+        fyne.NewMenuItemSeparator(),
+        fyne.NewMenuItem(tr("Compare Tab With Compare Database"), func() { compareWithDatabaseHandler(ctx) }),
+        fyne.NewMenuItem(tr("Compare Tab With Snapshot..."), func() { compareWithSnapshotHandler(ctx) }),
+        fyne.NewMenuItemSeparator(),
+        fyne.NewMenuItem(tr("Patch Notes From Compare Database"), func() { patchNotesFromDatabaseHandler(ctx) }),
+        fyne.NewMenuItem(tr("Patch Notes From Snapshot..."), func() { patchNotesFromSnapshotHandler(ctx) }),
+    )
+
+    mainMenu := fyne.NewMainMenu()
+
+    dryRunItem := fyne.NewMenuItem(tr("Dry-Run Mode"), nil)
+    dryRunItem.Checked = ctx.Patch != nil
+    dryRunItem.Action = func() {
+        toggleDryRunHandler(ctx, func() {
+            dryRunItem.Checked = ctx.Patch != nil
+            mainMenu.Refresh()
+        })
+    }
+    patchMenu := fyne.NewMenu(tr("Dry Run"),
+        dryRunItem,
+        fyne.NewMenuItemSeparator(),
+        fyne.NewMenuItem(tr("Save SQL Patch..."), func() { saveSQLPatchHandler(ctx) }),
+        fyne.NewMenuItem(tr("Patch Notes From Pending Changes"), func() { patchNotesFromPendingHandler(ctx) }),
+        fyne.NewMenuItem(tr("Discard Pending Changes"), func() { discardPendingHandler(ctx) }),
+        fyne.NewMenuItemSeparator(),
+        fyne.NewMenuItem(tr("Apply SQL Patch File..."), func() { applySQLPatchHandler(ctx) }),
+    )
+
+    if ctx.ReadOnly {
+        for _, item := range patchMenu.Items {
+            item.Disabled = true
+        }
+    }
+
+    renumberItem := fyne.NewMenuItem(tr("Renumber Talents..."), func() { showRenumberDialog(ctx, nil) })
+    renumberItem.Disabled = ctx.ReadOnly
+    cloneTabItem := fyne.NewMenuItem(tr("Clone Current Tab..."), func() { showCloneTabDialog(ctx) })
+    cloneTabItem.Disabled = ctx.ReadOnly
+    tabIconItem := fyne.NewMenuItem(tr("Icon Of Current Tab..."), func() { showTabIconPicker(ctx) })
+    tabIconItem.Disabled = ctx.ReadOnly
+    toolsMenu := fyne.NewMenu(tr("Tools"),
+        fyne.NewMenuItem(tr("Search Talents..."), func() { showSearchWindow(ctx) }),
+        fyne.NewMenuItemSeparator(),
+        cloneTabItem,
+        renumberItem,
+        fyne.NewMenuItemSeparator(),
+        fyne.NewMenuItem(tr("Class Mask Of Current Tab..."), func() { showClassMaskEditor(ctx) }),
+        fyne.NewMenuItem(tr("Class Tab Assignments"), func() { showClassAssignments(ctx) }),
+        tabIconItem,
+        fyne.NewMenuItem(tr("Names Of Current Tab..."), func() { showTabTranslations(ctx) }),
+        fyne.NewMenuItem(tr("Missing Translations"), func() { showMissingTranslations(ctx) }),
+        fyne.NewMenuItemSeparator(),
+        fyne.NewMenuItem(tr("Icon Gallery"), func() { showIconGallery(ctx) }),
+        fyne.NewMenuItem(tr("Missing Icon Report"), func() { showMissingIcons(ctx) }),
+    )
+
+    mainMenu.Items = []*fyne.Menu{compareMenu, patchMenu, toolsMenu}
+    return mainMenu
+}
+
+func saveSnapshotHandler(ctx *AppContext) {
+    data, err := loadTalentData(ctx, ctx.Config.DBC.Name)
+    if err != nil {
+        dialog.ShowError(err, ctx.Window)
+        return
+    }
+
+    save := dialog.NewFileSave(func(writer fyne.URIWriteCloser, err error) {
+        if err != nil {
+            dialog.ShowError(err, ctx.Window)
+            return
+        }
+        if writer == nil {
+            return
+        }
+        defer writer.Close()
+
+        data.Name = fmt.Sprintf("Snapshot %s", data.Created.Format("2006-01-02 15:04"))
+        if err := writeSnapshot(writer, data); err != nil {
+            dialog.ShowError(err, ctx.Window)
+        }
+    }, ctx.Window)
+    save.SetFileName(fmt.Sprintf("talents-%s.json", data.Created.Format("20060102-150405")))
+    save.Show()
+}
+
+func compareWithDatabaseHandler(ctx *AppContext) {
+    withCompareData(ctx, func(oldData *TalentData) { compareCurrentTab(ctx, oldData) })
+}
+
+func compareWithSnapshotHandler(ctx *AppContext) {
+    withSnapshotData(ctx, func(oldData *TalentData) { compareCurrentTab(ctx, oldData) })
+}
+
+func patchNotesFromDatabaseHandler(ctx *AppContext) {
+    withCompareData(ctx, func(oldData *TalentData) { showPatchNotes(ctx, oldData) })
+}
+
+func patchNotesFromSnapshotHandler(ctx *AppContext) {
+    withSnapshotData(ctx, func(oldData *TalentData) { showPatchNotes(ctx, oldData) })
+}
+
+// withCompareData loads the complete data state of the compare database
+func withCompareData(ctx *AppContext, fn func(data *TalentData)) {
+    other, err := openCompareDB(ctx)
+    if err != nil {
+        dialog.ShowError(err, ctx.Window)
+        return
+    }
+
+    data, err := loadTalentData(other, ctx.Config.Compare.Name)
+    if err != nil {
+        dialog.ShowError(err, ctx.Window)
+        return
+    }
+
+    fn(data)
+}
+
+// withSnapshotData asks for a snapshot file and loads it
+func withSnapshotData(ctx *AppContext, fn func(data *TalentData)) {
+    dialog.ShowFileOpen(func(reader fyne.URIReadCloser, err error) {
+        if err != nil {
+            dialog.ShowError(err, ctx.Window)
+            return
+        }
+        if reader == nil {
+            return
+        }
+        defer reader.Close()
+
+        data, err := readSnapshot(reader)
+        if err != nil {
+            dialog.ShowError(err, ctx.Window)
+            return
+        }
+        fn(data)
+    }, ctx.Window)
+}
+
+// toggleDryRunHandler switches dry-run mode, asking before pending statements are discarded
+func toggleDryRunHandler(ctx *AppContext, done func()) {
+    if ctx.Patch == nil {
+        ctx.Patch = NewSQLPatch()
+        updateWindowTitle(ctx)
+        done()
+        return
+    }
+
+    disable := func() {
+        ctx.Patch = nil
+        invalidateSpellCaches(ctx)
+        updateWindowTitle(ctx)
+        reloadCurrentTab(ctx)
+        done()
+    }
+    if len(ctx.Patch.Statements) == 0 {
+        disable()
+        return
+    }
+    dialog.ShowConfirm(tr("Disable Dry-Run Mode"),
+        trf("Discard %d pending statements? Save the SQL patch first to keep them.", len(ctx.Patch.Statements)),
+        func(yes bool) {
+            if yes {
+                disable()
+            }
+        }, ctx.Window)
+}
+
+func saveSQLPatchHandler(ctx *AppContext) {
+    if ctx.Patch == nil || len(ctx.Patch.Statements) == 0 {
+        dialog.ShowInformation(tr("Save SQL Patch"), tr("There are no pending changes. Enable dry-run mode and edit talents first."), ctx.Window)
+        return
+    }
+
+    save := dialog.NewFileSave(func(writer fyne.URIWriteCloser, err error) {
+        if err != nil {
+            dialog.ShowError(err, ctx.Window)
+            return
+        }
+        if writer == nil {
+            return
+        }
+        defer writer.Close()
+
+        if _, err := ctx.Patch.Export(writer, ctx.Config.DBC.Name); err != nil {
+            dialog.ShowError(err, ctx.Window)
+        }
+    }, ctx.Window)
+    save.SetFileName("talent-patch.sql")
+    save.Show()
+}
+
+func patchNotesFromPendingHandler(ctx *AppContext) {
+    if ctx.Patch == nil || len(ctx.Patch.Talents) == 0 {
+        dialog.ShowInformation(tr("Patch Notes"), tr("There are no pending talent changes."), ctx.Window)
+        return
+    }
+
+    oldData, err := loadTalentData(ctx, ctx.Config.DBC.Name)
+    if err != nil {
+        dialog.ShowError(err, ctx.Window)
+        return
+    }
+    newData, err := oldData.withPendingChanges(ctx, ctx.Patch, "Pending changes")
+    if err != nil {
+        dialog.ShowError(err, ctx.Window)
+        return
+    }
+    showPatchNotesWindow(ctx, oldData, newData)
+}
+
+func discardPendingHandler(ctx *AppContext) {
+    if ctx.Patch == nil || len(ctx.Patch.Statements) == 0 {
+        return
+    }
+    dialog.ShowConfirm(tr("Discard Pending Changes"),
+        trf("Discard %d pending statements?", len(ctx.Patch.Statements)),
+        func(yes bool) {
+            if !yes {
+                return
+            }
+            ctx.Patch = NewSQLPatch()
+            invalidateSpellCaches(ctx)
+            updateWindowTitle(ctx)
+            reloadCurrentTab(ctx)
+        }, ctx.Window)
+}
+
+// applySQLPatchHandler executes a .sql patch file against the database in one transaction
+func applySQLPatchHandler(ctx *AppContext) {
+    dialog.ShowFileOpen(func(reader fyne.URIReadCloser, err error) {
+        if err != nil {
+            dialog.ShowError(err, ctx.Window)
+            return
+        }
+        if reader == nil {
+            return
+        }
+        defer reader.Close()
+
+        data, err := io.ReadAll(reader)
+        if err != nil {
+            dialog.ShowError(err, ctx.Window)
+            return
+        }
+        script := string(data)
+        count := len(splitSQLStatements(script))
+
+        dialog.ShowConfirm(tr("Apply SQL Patch"),
+            trf("Execute %d statements from %s against %s?\nAll statements run in one transaction and are rolled back on the first error.",
+                count, reader.URI().Name(), ctx.Config.DBC.Name),
+            func(yes bool) {
+                if !yes {
+                    return
+                }
+                applied, err := applySQLPatch(ctx, script)
+                if err != nil {
+                    dialog.ShowError(err, ctx.Window)
+                    return
+                }
+                invalidateSpellCaches(ctx)
+                reloadCurrentTab(ctx)
+                dialog.ShowInformation(tr("Apply SQL Patch"), trf("Applied %d statements.", applied), ctx.Window)
+            }, ctx.Window)
+    }, ctx.Window)
+}
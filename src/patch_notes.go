@@ -0,0 +1,318 @@
+// Copyright (c) 2025 TalentEditor
+//
+// TalentEditor is licensed under the MIT License.
+// See the LICENSE file for details.
+
+package main
+
+import (
+    "fmt"
+    "html"
+    "sort"
+    "strings"
+
+    "fyne.io/fyne/v2"
+    "fyne.io/fyne/v2/container"
+    "fyne.io/fyne/v2/dialog"
+    "fyne.io/fyne/v2/widget"
+)
+
+// PatchNotes are human-readable change lines grouped by class and tab
+type PatchNotes struct {
+    Title   string
+    Classes []PatchNotesClass
+}
+
+type PatchNotesClass struct {
+    Name string
+    Tabs []PatchNotesTab
+}
+
+type PatchNotesTab struct {
+    Name  string
+    Lines []string
+}
+
+// buildPatchNotes turns the differences between two complete data states into patch notes.
+// Spell names of both states are resolved through GetSpellsByIDs when the data is loaded.
+func buildPatchNotes(title string, oldData, newData *TalentData, classMap map[int]ChrClass) PatchNotes {
+    changes := diffTalents(oldData.Talents, newData.Talents)
+
+    oldTalents := make(map[int]*Talent, len(oldData.Talents))
+    for i := range oldData.Talents {
+        oldTalents[oldData.Talents[i].ID] = &oldData.Talents[i]
+    }
+    newTalents := make(map[int]*Talent, len(newData.Talents))
+    for i := range newData.Talents {
+        newTalents[newData.Talents[i].ID] = &newData.Talents[i]
+    }
+
+    // class name -> tab name -> lines
+    grouped := make(map[string]map[string][]string)
+    addLine := func(data *TalentData, t *Talent, line string) {
+        tab, ok := data.Tabs[int(t.SpecID.Int64)]
+        tabName := fmt.Sprintf("tab_%d", t.SpecID.Int64)
+        classNames := []string{"Unknown"}
+        if ok {
+            tabName = tab.NameENUS
+            if isPetTab(tab) {
+                classNames = []string{"Pet"}
+            } else {
+                classNames = tabClassNames(tab, classMap)
+            }
+        }
+        for _, className := range classNames {
+            if grouped[className] == nil {
+                grouped[className] = make(map[string][]string)
+            }
+            grouped[className][tabName] = append(grouped[className][tabName], line)
+        }
+    }
+
+    for _, c := range changes {
+        switch c.Kind {
+        case ChangeAdded:
+            addLine(newData, c.New, fmt.Sprintf("New talent: %s", talentName(c.New, newData.Spells)))
+        case ChangeRemoved:
+            addLine(oldData, c.Old, fmt.Sprintf("Removed talent: %s", talentName(c.Old, oldData.Spells)))
+        default:
+            for _, line := range describeTalentChange(c, oldData, newData, oldTalents, newTalents) {
+                addLine(newData, c.New, line)
+            }
+        }
+    }
+
+    notes := PatchNotes{Title: title}
+    classNames := make([]string, 0, len(grouped))
+    for name := range grouped {
+        classNames = append(classNames, name)
+    }
+    sort.Strings(classNames)
+    for _, className := range classNames {
+        class := PatchNotesClass{Name: className}
+        tabNames := make([]string, 0, len(grouped[className]))
+        for name := range grouped[className] {
+            tabNames = append(tabNames, name)
+        }
+        sort.Strings(tabNames)
+        for _, tabName := range tabNames {
+            class.Tabs = append(class.Tabs, PatchNotesTab{Name: tabName, Lines: grouped[className][tabName]})
+        }
+        notes.Classes = append(notes.Classes, class)
+    }
+    return notes
+}
+
+// describeTalentChange returns the patch note lines for a talent present in both states
+func describeTalentChange(c TalentChange, oldData, newData *TalentData, oldTalents, newTalents map[int]*Talent) []string {
+    var lines []string
+    o, n := c.Old, c.New
+    oldName := talentName(o, oldData.Spells)
+    name := talentName(n, newData.Spells)
+
+    if oldName != name {
+        lines = append(lines, fmt.Sprintf("Renamed %s to %s", oldName, name))
+    }
+
+    oldRanks, newRanks := talentRankCount(o), talentRankCount(n)
+    if oldRanks != newRanks {
+        lines = append(lines, fmt.Sprintf("%s: now %s (was %d)", name, pluralize(newRanks, "rank"), oldRanks))
+    } else {
+        var changedRanks []string
+        for i := 0; i < 9; i++ {
+            if nullIntString(o.Rank[i]) != nullIntString(n.Rank[i]) {
+                changedRanks = append(changedRanks, fmt.Sprintf("%d", i+1))
+            }
+        }
+        if len(changedRanks) > 0 {
+            lines = append(lines, fmt.Sprintf("%s: updated rank %s", name, strings.Join(changedRanks, ", ")))
+        }
+    }
+
+    switch {
+    case nullIntString(o.SpecID) != nullIntString(n.SpecID):
+        from := fmt.Sprintf("tab_%d", o.SpecID.Int64)
+        if tab, ok := oldData.Tabs[int(o.SpecID.Int64)]; ok {
+            from = tab.NameENUS
+        }
+        to := fmt.Sprintf("tab_%d", n.SpecID.Int64)
+        if tab, ok := newData.Tabs[int(n.SpecID.Int64)]; ok {
+            to = tab.NameENUS
+        }
+        lines = append(lines, fmt.Sprintf("Moved %s from %s to %s, tier %d", name, from, to, n.TierID.Int64+1))
+    case nullIntString(o.TierID) != nullIntString(n.TierID):
+        lines = append(lines, fmt.Sprintf("Moved %s to tier %d", name, n.TierID.Int64+1))
+    case nullIntString(o.ColumnIndex) != nullIntString(n.ColumnIndex):
+        lines = append(lines, fmt.Sprintf("Moved %s to column %d", name, n.ColumnIndex.Int64+1))
+    }
+
+    oldReqs, newReqs := talentPreReqIDs(o), talentPreReqIDs(n)
+    for _, id := range sortedKeys(newReqs) {
+        if !oldReqs[id] {
+            lines = append(lines, fmt.Sprintf("%s: now requires %s", name, preReqName(id, newData, newTalents)))
+        }
+    }
+    for _, id := range sortedKeys(oldReqs) {
+        if !newReqs[id] {
+            lines = append(lines, fmt.Sprintf("%s: no longer requires %s", name, preReqName(id, oldData, oldTalents)))
+        }
+    }
+
+    if nullIntString(o.ReqSpellID) != nullIntString(n.ReqSpellID) {
+        if n.ReqSpellID.Valid && n.ReqSpellID.Int64 != 0 {
+            lines = append(lines, fmt.Sprintf("%s: now requires spell %d", name, n.ReqSpellID.Int64))
+        } else {
+            lines = append(lines, fmt.Sprintf("%s: no longer requires a spell", name))
+        }
+    }
+    if nullIntString(o.Flags) != nullIntString(n.Flags) {
+        lines = append(lines, fmt.Sprintf("%s: flags changed", name))
+    }
+    if nullIntString(o.AllowForPetFlags1) != nullIntString(n.AllowForPetFlags1) ||
+        nullIntString(o.AllowForPetFlags2) != nullIntString(n.AllowForPetFlags2) {
+        lines = append(lines, fmt.Sprintf("%s: pet family availability changed", name))
+    }
+
+    return lines
+}
+
+// talentRankCount counts the ranks with a spell assigned
+func talentRankCount(t *Talent) int {
+    count := 0
+    for _, r := range t.Rank {
+        if r.Valid && r.Int64 != 0 {
+            count++
+        }
+    }
+    return count
+}
+
+// talentPreReqIDs returns the set of prerequisite talent IDs
+func talentPreReqIDs(t *Talent) map[int]bool {
+    ids := make(map[int]bool)
+    for _, p := range t.PreReqTalent {
+        if p.Valid && p.Int64 != 0 {
+            ids[int(p.Int64)] = true
+        }
+    }
+    return ids
+}
+
+func sortedKeys(set map[int]bool) []int {
+    keys := make([]int, 0, len(set))
+    for k := range set {
+        keys = append(keys, k)
+    }
+    sort.Ints(keys)
+    return keys
+}
+
+func preReqName(id int, data *TalentData, talents map[int]*Talent) string {
+    if t, ok := talents[id]; ok {
+        return talentName(t, data.Spells)
+    }
+    return fmt.Sprintf("Talent %d", id)
+}
+
+func pluralize(n int, word string) string {
+    if n == 1 {
+        return fmt.Sprintf("%d %s", n, word)
+    }
+    return fmt.Sprintf("%d %ss", n, word)
+}
+
+// Markdown renders the patch notes as a Markdown document
+func (p PatchNotes) Markdown() string {
+    var sb strings.Builder
+    fmt.Fprintf(&sb, "# %s\n\n", p.Title)
+    if len(p.Classes) == 0 {
+        sb.WriteString("No talent changes.\n")
+    }
+    for _, class := range p.Classes {
+        fmt.Fprintf(&sb, "## %s\n\n", class.Name)
+        for _, tab := range class.Tabs {
+            fmt.Fprintf(&sb, "### %s\n\n", tab.Name)
+            for _, line := range tab.Lines {
+                fmt.Fprintf(&sb, "* %s\n", line)
+            }
+            sb.WriteString("\n")
+        }
+    }
+    return sb.String()
+}
+
+// HTML renders the patch notes as a standalone HTML document
+func (p PatchNotes) HTML() string {
+    var sb strings.Builder
+    title := html.EscapeString(p.Title)
+    fmt.Fprintf(&sb, "<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n<title>%s</title>\n</head>\n<body>\n", title)
+    fmt.Fprintf(&sb, "<h1>%s</h1>\n", title)
+    if len(p.Classes) == 0 {
+        sb.WriteString("<p>No talent changes.</p>\n")
+    }
+    for _, class := range p.Classes {
+        fmt.Fprintf(&sb, "<h2>%s</h2>\n", html.EscapeString(class.Name))
+        for _, tab := range class.Tabs {
+            fmt.Fprintf(&sb, "<h3>%s</h3>\n<ul>\n", html.EscapeString(tab.Name))
+            for _, line := range tab.Lines {
+                fmt.Fprintf(&sb, "  <li>%s</li>\n", html.EscapeString(line))
+            }
+            sb.WriteString("</ul>\n")
+        }
+    }
+    sb.WriteString("</body>\n</html>\n")
+    return sb.String()
+}
+
+// showPatchNotes generates patch notes from the old data state to the live database and shows a preview
+func showPatchNotes(ctx *AppContext, oldData *TalentData) {
+    newData, err := loadTalentData(ctx, ctx.Config.DBC.Name)
+    if err != nil {
+        dialog.ShowError(err, ctx.Window)
+        return
+    }
+    showPatchNotesWindow(ctx, oldData, newData)
+}
+
+// showPatchNotesWindow previews the patch notes between two data states with Markdown and HTML export
+func showPatchNotesWindow(ctx *AppContext, oldData, newData *TalentData) {
+    classMap, err := GetAllClasses(ctx)
+    if err != nil {
+        dialog.ShowError(err, ctx.Window)
+        return
+    }
+
+    notes := buildPatchNotes(fmt.Sprintf("Talent changes: %s → %s", oldData.Name, newData.Name), oldData, newData, classMap)
+    markdown := notes.Markdown()
+
+    w := fyne.CurrentApp().NewWindow(tr("Patch Notes"))
+    preview := widget.NewRichTextFromMarkdown(markdown)
+    preview.Wrapping = fyne.TextWrapWord
+
+    saveAs := func(fileName, content string) {
+        save := dialog.NewFileSave(func(writer fyne.URIWriteCloser, err error) {
+            if err != nil {
+                dialog.ShowError(err, w)
+                return
+            }
+            if writer == nil {
+                return
+            }
+            defer writer.Close()
+            if _, err := writer.Write([]byte(content)); err != nil {
+                dialog.ShowError(err, w)
+            }
+        }, w)
+        save.SetFileName(fileName)
+        save.Show()
+    }
+
+    buttons := container.NewHBox(
+        widget.NewButton(tr("Save Markdown..."), func() { saveAs("patch-notes.md", markdown) }),
+        widget.NewButton(tr("Save HTML..."), func() { saveAs("patch-notes.html", notes.HTML()) }),
+    )
+
+    w.SetContent(container.NewBorder(nil, buttons, nil, nil, container.NewVScroll(preview)))
+    w.Resize(fyne.NewSize(800, 900))
+    w.Show()
+}
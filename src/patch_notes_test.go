@@ -0,0 +1,34 @@
+// Copyright (c) 2025 TalentEditor
+//
+// TalentEditor is licensed under the MIT License.
+// See the LICENSE file for details.
+
+package main
+
+import (
+    "database/sql"
+    "strings"
+    "testing"
+)
+
+func TestDescribeTalentChangeLocale(t *testing.T) {
+    talent := Talent{ID: 7}
+    talent.Rank[0] = sql.NullInt64{Int64: 1000, Valid: true}
+    // The snapshot was taken in deDE, the current state is loaded in enUS
+    oldData := &TalentData{Spells: map[int]Spell{1000: {ID: 1000, NameENUS: "Improved Fireball", LocalName: "Verbesserter Feuerball"}}}
+    newData := &TalentData{Spells: map[int]Spell{1000: {ID: 1000, NameENUS: "Improved Fireball"}}}
+
+    o, n := talent, talent
+    lines := describeTalentChange(TalentChange{ID: 7, Old: &o, New: &n}, oldData, newData, nil, nil)
+    for _, l := range lines {
+        if strings.HasPrefix(l, "Renamed") {
+            t.Errorf("patch notes report a locale difference as a rename: %q", l)
+        }
+    }
+
+    newData.Spells[1000] = Spell{ID: 1000, NameENUS: "Greater Fireball"}
+    lines = describeTalentChange(TalentChange{ID: 7, Old: &o, New: &n}, oldData, newData, nil, nil)
+    if len(lines) == 0 || lines[0] != "Renamed Improved Fireball to Greater Fireball" {
+        t.Errorf("patch notes = %q, want the enUS rename", lines)
+    }
+}
@@ -0,0 +1,415 @@
+// Copyright (c) 2025 TalentEditor
+//
+// TalentEditor is licensed under the MIT License.
+// See the LICENSE file for details.
+
+package main
+
+import (
+    "database/sql"
+    "fmt"
+    "math"
+    "strconv"
+    "strings"
+
+    "fyne.io/fyne/v2"
+    "fyne.io/fyne/v2/container"
+    "fyne.io/fyne/v2/dialog"
+    "fyne.io/fyne/v2/widget"
+)
+
+// RankScaleMode selects how the rank wizard computes the effect values of each rank
+type RankScaleMode int
+
+const (
+    RankScaleLinear  RankScaleMode = iota // template value plus a step per rank
+    RankScaleList                         // one value per rank
+    RankScaleFormula                      // expression over r (rank), v (template value) and n (rank count)
+)
+
+var rankScaleModeNames = []string{"Linear", "Custom list", "Formula"}
+
+// RankWizardOptions describe the rank spells generated from a template spell.
+// Values are magnitudes, every effect keeps the sign of its template value.
+type RankWizardOptions struct {
+    TemplateID  int
+    Ranks       int
+    Effects     [3]bool // effects whose base points are scaled
+    Mode        RankScaleMode
+    Step        float64 // linear step, 0 adds the template value per rank
+    Values      []float64
+    Formula     string
+    RewriteDesc bool // replace the template values written as plain numbers in the texts
+}
+
+// RankSpell is one generated rank spell, the ID is allocated when it is written
+type RankSpell struct {
+    Rank       int
+    BasePoints [3]int64
+    Values     [3]float64
+    Desc       string
+    Tooltip    string
+}
+
+// rankValue computes the magnitude of an effect value for a rank, v being the template value
+func rankValue(opts RankWizardOptions, rank int, v float64) (float64, error) {
+    switch opts.Mode {
+    case RankScaleList:
+        if rank > len(opts.Values) {
+            return 0, fmt.Errorf("the custom list has %d values, %d ranks are generated", len(opts.Values), opts.Ranks)
+        }
+        return opts.Values[rank-1], nil
+    case RankScaleFormula:
+        p := &exprParser{s: opts.Formula, vars: map[string]float64{"r": float64(rank), "v": v, "n": float64(opts.Ranks)}}
+        value, ok := p.sum()
+        p.skipSpace()
+        if !ok || p.pos != len(p.s) {
+            return 0, fmt.Errorf("invalid formula %q, use numbers, + - * / ( ) and the variables r, v and n", opts.Formula)
+        }
+        return value, nil
+    }
+    step := opts.Step
+    if step == 0 {
+        step = v
+    }
+    return v + float64(rank-1)*step, nil
+}
+
+// planRankSpells computes the base points and texts of every rank from the template spell
+func planRankSpells(template *SpellEdit, effects SpellEffects, opts RankWizardOptions) ([]RankSpell, error) {
+    if opts.Ranks < 1 || opts.Ranks > 9 {
+        return nil, fmt.Errorf("a talent has 1 to 9 ranks, not %d", opts.Ranks)
+    }
+
+    var ranks []RankSpell
+    for r := 1; r <= opts.Ranks; r++ {
+        rank := RankSpell{Rank: r, BasePoints: template.BasePoints}
+        replace := make(map[string]string)
+        for i := range rank.BasePoints {
+            // The client shows base points + 1 for effects with die sides
+            offset := min(effects.DieSides[i], 1)
+            templateValue := template.BasePoints[i] + offset
+            rank.Values[i] = math.Abs(float64(templateValue))
+            if !opts.Effects[i] {
+                continue
+            }
+
+            value, err := rankValue(opts, r, math.Abs(float64(templateValue)))
+            if err != nil {
+                return nil, err
+            }
+            value = math.Round(math.Abs(value))
+            rank.Values[i] = value
+            if templateValue < 0 {
+                value = -value
+            }
+            rank.BasePoints[i] = int64(value) - offset
+            if templateValue != 0 {
+                replace[formatDescNumber(math.Abs(float64(templateValue)))] = formatDescNumber(rank.Values[i])
+            }
+        }
+
+        rank.Desc, rank.Tooltip = template.Desc, template.Tooltip
+        if opts.RewriteDesc {
+            rank.Desc = rewriteDescNumbers(template.Desc, replace)
+            rank.Tooltip = rewriteDescNumbers(template.Tooltip, replace)
+        }
+        ranks = append(ranks, rank)
+    }
+    return ranks, nil
+}
+
+// rewriteDescNumbers replaces plain numbers in a spell text, leaving $ tokens and
+// ${...} expressions alone since they follow the new base points by themselves
+func rewriteDescNumbers(desc string, replace map[string]string) string {
+    isDigit := func(c byte) bool { return c >= '0' && c <= '9' }
+    isLetter := func(c byte) bool { return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' }
+
+    var sb strings.Builder
+    for i := 0; i < len(desc); {
+        c := desc[i]
+        switch {
+        case strings.HasPrefix(desc[i:], "${"):
+            end := strings.IndexByte(desc[i:], '}')
+            if end < 0 {
+                end = len(desc) - i - 1
+            }
+            sb.WriteString(desc[i : i+end+1])
+            i += end + 1
+        case c == '$':
+            j := i + 1
+            for j < len(desc) && (isLetter(desc[j]) || isDigit(desc[j]) || strings.IndexByte("/*;", desc[j]) >= 0) {
+                j++
+            }
+            sb.WriteString(desc[i:j])
+            i = j
+        case isDigit(c):
+            j := i
+            for j < len(desc) && (isDigit(desc[j]) || desc[j] == '.' && j+1 < len(desc) && isDigit(desc[j+1])) {
+                j++
+            }
+            number := desc[i:j]
+            if r, ok := replace[number]; ok && (i == 0 || !isLetter(desc[i-1])) {
+                number = r
+            }
+            sb.WriteString(number)
+            i = j
+        default:
+            sb.WriteByte(c)
+            i++
+        }
+    }
+    return sb.String()
+}
+
+// generateRankSpells copies the template spell once per rank with new spell IDs and
+// sets them as the ranks of the talent, in one transaction. Returns the updated talent.
+func generateRankSpells(ctx *AppContext, t *Talent, template *SpellEdit, ranks []RankSpell) (*Talent, error) {
+    updated := *t
+    ids := make([]int, len(ranks))
+    err := runWrite(ctx, func(w *WriteTx) error {
+        var maxID int64
+        if err := w.QueryRow("SELECT COALESCE(MAX(id), 0) FROM Spell FOR UPDATE").Scan(&maxID); err != nil {
+            return err
+        }
+        if pending := w.MaxPendingID("Spell"); pending > maxID {
+            maxID = pending
+        }
+
+        // Copy the whole row so columns the editor does not know about are kept. A rollback
+        // does not drop temporary tables, so one left by a failed run is dropped first.
+        if _, err := w.Exec("DROP TEMPORARY TABLE IF EXISTS spell_rank_clone"); err != nil {
+            return err
+        }
+        if _, err := w.Exec("CREATE TEMPORARY TABLE spell_rank_clone SELECT * FROM Spell WHERE id = ?", template.ID); err != nil {
+            return err
+        }
+        for i, rank := range ranks {
+            ids[i] = int(maxID) + i + 1
+            if _, err := w.Exec(`UPDATE spell_rank_clone SET id = ?, spell_rank_enus = ?,
+                spell_desc_enus = ?, spell_tooltip_enus = ?,
+                effect_base_points_1 = ?, effect_base_points_2 = ?, effect_base_points_3 = ?`,
+                ids[i], fmt.Sprintf("Rank %d", rank.Rank), rank.Desc, rank.Tooltip,
+                rank.BasePoints[0], rank.BasePoints[1], rank.BasePoints[2]); err != nil {
+                return err
+            }
+            if _, err := w.Exec("INSERT INTO Spell SELECT * FROM spell_rank_clone"); err != nil {
+                return idInUseError(err, "spell", ids[i])
+            }
+            w.TrackID("Spell", ids[i])
+        }
+        if _, err := w.Exec("DROP TEMPORARY TABLE spell_rank_clone"); err != nil {
+            return err
+        }
+
+        for i := range updated.Rank {
+            updated.Rank[i] = sql.NullInt64{Int64: 0, Valid: true}
+            if i < len(ids) {
+                updated.Rank[i].Int64 = int64(ids[i])
+            }
+        }
+        query, args := UpdateTalentQuery(&updated)
+        if _, err := w.Exec(query, args...); err != nil {
+            return err
+        }
+        w.TrackTalent(updated.ID, &updated)
+        return nil
+    })
+    if err != nil {
+        return nil, err
+    }
+
+    // The new IDs may have been cached as missing before. In dry-run mode the caches
+    // hold the pending spells instead so the grid can show them.
+    for i, id := range ids {
+        if ctx.Patch == nil {
+            delete(ctx.Spells, id)
+            delete(ctx.SpellEffects, id)
+            continue
+        }
+        if ctx.Spells == nil {
+            ctx.Spells = make(map[int]Spell)
+        }
+        ctx.Spells[id] = Spell{ID: id, NameENUS: template.Name, IconID: sql.NullInt64{Int64: template.IconID, Valid: true}, Desc: ranks[i].Desc}
+        if effects, ok := ctx.SpellEffects[template.ID]; ok {
+            effects.ID = id
+            effects.BasePoints = ranks[i].BasePoints
+            ctx.SpellEffects[id] = effects
+        }
+    }
+    return &updated, nil
+}
+
+// parseRankValues parses the comma separated values of the custom list mode
+func parseRankValues(text string) ([]float64, error) {
+    var values []float64
+    for _, part := range strings.Split(text, ",") {
+        part = strings.TrimSpace(part)
+        if part == "" {
+            continue
+        }
+        v, err := strconv.ParseFloat(part, 64)
+        if err != nil {
+            return nil, fmt.Errorf("invalid value %q in the custom list", part)
+        }
+        values = append(values, v)
+    }
+    return values, nil
+}
+
+// rankPlanSummary lists the effect values and descriptions of the planned ranks
+func rankPlanSummary(ranks []RankSpell, opts RankWizardOptions) string {
+    var lines []string
+    for _, rank := range ranks {
+        var values []string
+        for i, v := range rank.Values {
+            if opts.Effects[i] {
+                values = append(values, trf("effect %d = %s", i+1, formatDescNumber(v)))
+            }
+        }
+        lines = append(lines, trf("Rank %d: %s", rank.Rank, strings.Join(values, ", ")))
+        if opts.RewriteDesc && rank.Desc != "" {
+            lines = append(lines, "    "+rank.Desc)
+        }
+    }
+    return strings.Join(lines, "\n")
+}
+
+// showRankWizard asks for a template spell and the scaling of its effects, previews the
+// generated ranks and writes them. onDone receives the talent with its new ranks.
+func showRankWizard(ctx *AppContext, t *Talent, onDone func(updated *Talent)) {
+    templateEntry := widget.NewEntry()
+    if t.Rank[0].Valid && t.Rank[0].Int64 > 0 {
+        templateEntry.SetText(strconv.FormatInt(t.Rank[0].Int64, 10))
+    }
+    rankOptions := make([]string, 9)
+    for i := range rankOptions {
+        rankOptions[i] = strconv.Itoa(i + 1)
+    }
+    ranksSelect := widget.NewSelect(rankOptions, nil)
+    // Talents without ranks yet default to 5
+    ranks := talentRankCount(t)
+    if ranks == 0 {
+        ranks = 5
+    }
+    ranksSelect.SetSelected(strconv.Itoa(ranks))
+
+    effectChecks := make([]*widget.Check, 3)
+    for i := range effectChecks {
+        effectChecks[i] = widget.NewCheck(trf("Effect %d", i+1), nil)
+    }
+    effectChecks[0].SetChecked(true)
+
+    stepEntry := widget.NewEntry()
+    stepEntry.SetPlaceHolder(tr("added per rank, empty or 0 for the template value"))
+    listEntry := widget.NewEntry()
+    listEntry.SetPlaceHolder(tr("e.g. 1, 2, 3, 4, 5"))
+    formulaEntry := widget.NewEntry()
+    formulaEntry.SetPlaceHolder(tr("e.g. v * r or (v + 2) * r - 2"))
+    modeNames := make([]string, len(rankScaleModeNames))
+    for i, name := range rankScaleModeNames {
+        modeNames[i] = tr(name)
+    }
+    modeRadio := widget.NewRadioGroup(modeNames, func(mode string) {
+        for i, e := range []*widget.Entry{stepEntry, listEntry, formulaEntry} {
+            if modeNames[i] == mode {
+                e.Enable()
+            } else {
+                e.Disable()
+            }
+        }
+    })
+    modeRadio.Horizontal = true
+    modeRadio.Required = true
+    modeRadio.SetSelected(modeNames[RankScaleLinear])
+
+    rewriteCheck := widget.NewCheck(tr("Rewrite plain numbers in description and tooltip"), nil)
+    rewriteCheck.SetChecked(true)
+
+    items := []*widget.FormItem{
+        widget.NewFormItem(tr("Template spell ID"), templateEntry),
+        widget.NewFormItem(tr("Ranks"), ranksSelect),
+        widget.NewFormItem(tr("Scale"), container.NewHBox(effectChecks[0], effectChecks[1], effectChecks[2])),
+        widget.NewFormItem(tr("Mode"), modeRadio),
+        widget.NewFormItem(tr("Step"), stepEntry),
+        widget.NewFormItem(tr("Values"), listEntry),
+        widget.NewFormItem(tr("Formula"), formulaEntry),
+        widget.NewFormItem("", rewriteCheck),
+    }
+
+    form := dialog.NewForm(trf("Rank Wizard - Talent %d", t.ID), tr("Preview"), tr("Cancel"), items, func(ok bool) {
+        if !ok {
+            return
+        }
+
+        var opts RankWizardOptions
+        var err error
+        if opts.TemplateID, err = strconv.Atoi(strings.TrimSpace(templateEntry.Text)); err != nil || opts.TemplateID <= 0 {
+            dialog.ShowError(fmt.Errorf("invalid template spell ID %q", templateEntry.Text), ctx.Window)
+            return
+        }
+        opts.Ranks, _ = strconv.Atoi(ranksSelect.Selected)
+        for i, c := range effectChecks {
+            opts.Effects[i] = c.Checked
+        }
+        for i, name := range modeNames {
+            if modeRadio.Selected == name {
+                opts.Mode = RankScaleMode(i)
+            }
+        }
+        opts.RewriteDesc = rewriteCheck.Checked
+        switch opts.Mode {
+        case RankScaleLinear:
+            if text := strings.TrimSpace(stepEntry.Text); text != "" {
+                if opts.Step, err = strconv.ParseFloat(text, 64); err != nil {
+                    dialog.ShowError(fmt.Errorf("invalid step %q", stepEntry.Text), ctx.Window)
+                    return
+                }
+            }
+        case RankScaleList:
+            if opts.Values, err = parseRankValues(listEntry.Text); err != nil {
+                dialog.ShowError(err, ctx.Window)
+                return
+            }
+        case RankScaleFormula:
+            opts.Formula = strings.TrimSpace(formulaEntry.Text)
+        }
+
+        template, err := GetSpellForEdit(ctx, opts.TemplateID)
+        if err != nil {
+            dialog.ShowError(err, ctx.Window)
+            return
+        }
+        effects, err := GetSpellEffects(ctx, []int{opts.TemplateID})
+        if err != nil {
+            dialog.ShowError(err, ctx.Window)
+            return
+        }
+        ranks, err := planRankSpells(template, effects[opts.TemplateID], opts)
+        if err != nil {
+            dialog.ShowError(err, ctx.Window)
+            return
+        }
+
+        summary := widget.NewLabel(rankPlanSummary(ranks, opts))
+        summary.Wrapping = fyne.TextWrapWord
+        scroll := container.NewVScroll(summary)
+        scroll.SetMinSize(fyne.NewSize(500, 300))
+        title := trn(len(ranks), "Generate %d rank spell from %d - %s", "Generate %d rank spells from %d - %s", template.ID, template.Name)
+        dialog.ShowCustomConfirm(title, tr("Generate"), tr("Cancel"), scroll, func(ok bool) {
+            if !ok {
+                return
+            }
+            updated, err := generateRankSpells(ctx, t, template, ranks)
+            if err != nil {
+                dialog.ShowError(err, ctx.Window)
+                return
+            }
+            if onDone != nil {
+                onDone(updated)
+            }
+        }, ctx.Window)
+    }, ctx.Window)
+    form.Resize(fyne.NewSize(550, 0))
+    form.Show()
+}
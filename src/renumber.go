@@ -0,0 +1,374 @@
+// Copyright (c) 2025 TalentEditor
+//
+// TalentEditor is licensed under the MIT License.
+// See the LICENSE file for details.
+
+package main
+
+import (
+    "database/sql"
+    "fmt"
+    "sort"
+    "strconv"
+    "strings"
+
+    "fyne.io/fyne/v2"
+    "fyne.io/fyne/v2/container"
+    "fyne.io/fyne/v2/dialog"
+    "fyne.io/fyne/v2/widget"
+)
+
+// RenumberPlan moves a set of talents to a new, contiguous ID range and rewrites
+// every prerequisite reference pointing at them
+type RenumberPlan struct {
+    Mapping  map[int]int // old ID -> new ID
+    Order    []int       // old IDs, ascending
+    Talents  map[int]*Talent
+    Rewrites []PreReqRewrite
+}
+
+// PreReqRewrite is a talent whose prerequisite talent IDs change
+type PreReqRewrite struct {
+    TalentID int // ID after renumbering
+    Old      [3]sql.NullInt64
+    New      [3]sql.NullInt64
+}
+
+// planRenumber maps the selected talents, in ascending ID order, to start, start+1, ...
+func planRenumber(all []Talent, selected []int, start int) (*RenumberPlan, error) {
+    if len(selected) == 0 {
+        return nil, fmt.Errorf("no talents selected")
+    }
+    if start < 1 {
+        return nil, fmt.Errorf("the new start ID must be positive")
+    }
+
+    plan := &RenumberPlan{Mapping: make(map[int]int), Talents: make(map[int]*Talent)}
+    for i := range all {
+        plan.Talents[all[i].ID] = &all[i]
+    }
+
+    order := append([]int(nil), selected...)
+    sort.Ints(order)
+    for i, id := range order {
+        if _, ok := plan.Talents[id]; !ok {
+            return nil, fmt.Errorf("talent %d does not exist", id)
+        }
+        if i > 0 && order[i-1] == id {
+            return nil, fmt.Errorf("talent %d is selected twice", id)
+        }
+        plan.Mapping[id] = start + i
+    }
+    plan.Order = order
+
+    if err := plan.checkClashes(func(id int) bool { return plan.Talents[id] != nil }); err != nil {
+        return nil, err
+    }
+
+    // Prerequisite references to moved talents, across all tabs
+    for _, t := range all {
+        newPre := t.PreReqTalent
+        changed := false
+        for i, p := range t.PreReqTalent {
+            if !p.Valid || p.Int64 == 0 {
+                continue
+            }
+            if newID, ok := plan.Mapping[int(p.Int64)]; ok {
+                newPre[i] = sql.NullInt64{Int64: int64(newID), Valid: true}
+                changed = true
+            }
+        }
+        if !changed {
+            continue
+        }
+
+        id := t.ID
+        if newID, ok := plan.Mapping[id]; ok {
+            id = newID
+        }
+        plan.Rewrites = append(plan.Rewrites, PreReqRewrite{TalentID: id, Old: t.PreReqTalent, New: newPre})
+    }
+    sort.Slice(plan.Rewrites, func(i, j int) bool { return plan.Rewrites[i].TalentID < plan.Rewrites[j].TalentID })
+
+    return plan, nil
+}
+
+// checkClashes fails when a new ID is used by a talent that keeps its ID
+func (p *RenumberPlan) checkClashes(exists func(id int) bool) error {
+    for _, id := range p.Order {
+        newID := p.Mapping[id]
+        if _, moving := p.Mapping[newID]; exists(newID) && !moving {
+            return fmt.Errorf("new ID %d is already used by a talent outside the selection", newID)
+        }
+    }
+    return nil
+}
+
+// Report describes the plan for the dry-run preview
+func (p *RenumberPlan) Report() string {
+    var sb strings.Builder
+    sb.WriteString(trn(len(p.Order), "Renumber %d talent:", "Renumber %d talents:") + "\n")
+    for _, id := range p.Order {
+        fmt.Fprintf(&sb, "  %d → %d\n", id, p.Mapping[id])
+    }
+
+    sb.WriteString("\n" + trn(len(p.Rewrites), "Rewrite prerequisites of %d talent:", "Rewrite prerequisites of %d talents:") + "\n")
+    for _, r := range p.Rewrites {
+        for i := 0; i < 3; i++ {
+            if nullIntString(r.Old[i]) != nullIntString(r.New[i]) {
+                sb.WriteString(trf("  Talent %d, Pre-requisite Talent ID %d: %s → %s",
+                    r.TalentID, i+1, nullIntString(r.Old[i]), nullIntString(r.New[i])) + "\n")
+            }
+        }
+    }
+    return sb.String()
+}
+
+// applyRenumber executes the plan in one transaction. Talents are first moved to
+// temporary IDs above every used ID so overlapping old and new ranges cannot collide.
+// The new IDs are checked again inside the transaction, talents may have been created
+// since the preview.
+func applyRenumber(ctx *AppContext, plan *RenumberPlan) error {
+    return runWrite(ctx, func(w *WriteTx) error {
+        newIDs := IDRange{Min: plan.Mapping[plan.Order[0]], Max: plan.Mapping[plan.Order[len(plan.Order)-1]]}
+        used, err := usedTalentIDs(w, newIDs)
+        if err != nil {
+            return err
+        }
+        if err := plan.checkClashes(func(id int) bool { return used[id] }); err != nil {
+            return fmt.Errorf("%w, another editor may have created it since the preview", err)
+        }
+
+        var tempBase int
+        if err := w.QueryRow("SELECT COALESCE(MAX(id), 0) FROM Talent FOR UPDATE").Scan(&tempBase); err != nil {
+            return err
+        }
+        for id := range plan.Talents {
+            if id > tempBase {
+                tempBase = id
+            }
+        }
+        for _, id := range plan.Mapping {
+            if id > tempBase {
+                tempBase = id
+            }
+        }
+        if pending := int(w.MaxPendingTalentID()); pending > tempBase {
+            tempBase = pending
+        }
+        tempBase++
+
+        for i, id := range plan.Order {
+            if _, err := w.Exec("UPDATE Talent SET id = ? WHERE id = ?", tempBase+i, id); err != nil {
+                return err
+            }
+        }
+        for i, id := range plan.Order {
+            if _, err := w.Exec("UPDATE Talent SET id = ? WHERE id = ?", plan.Mapping[id], tempBase+i); err != nil {
+                return err
+            }
+        }
+        for _, r := range plan.Rewrites {
+            _, err := w.Exec("UPDATE Talent SET pre_req_talent_1 = ?, pre_req_talent_2 = ?, pre_req_talent_3 = ? WHERE id = ?",
+                nullInt64ToInterface(r.New[0]), nullInt64ToInterface(r.New[1]), nullInt64ToInterface(r.New[2]), r.TalentID)
+            if err != nil {
+                return err
+            }
+        }
+
+        // Pending state for the dry-run overlay
+        if w.DryRun() {
+            rewrites := make(map[int][3]sql.NullInt64)
+            for _, r := range plan.Rewrites {
+                rewrites[r.TalentID] = r.New
+            }
+            for _, id := range plan.Order {
+                w.TrackTalent(id, nil)
+            }
+            for id, t := range plan.Talents {
+                newID := id
+                if mapped, ok := plan.Mapping[id]; ok {
+                    newID = mapped
+                }
+                pre, rewritten := rewrites[newID]
+                if newID == id && !rewritten {
+                    continue
+                }
+                moved := *t
+                moved.ID = newID
+                if rewritten {
+                    moved.PreReqTalent = pre
+                }
+                w.TrackTalent(newID, &moved)
+            }
+        }
+        return nil
+    })
+}
+
+// maxIDListRange is the largest ID range parseIDList expands
+const maxIDListRange = 10000
+
+// parseIDList parses "1, 5, 10-20" into talent IDs
+func parseIDList(s string) ([]int, error) {
+    var ids []int
+    for _, part := range strings.FieldsFunc(s, func(r rune) bool { return r == ',' || r == ' ' || r == ';' }) {
+        if from, to, ok := strings.Cut(part, "-"); ok {
+            lo, err1 := strconv.Atoi(from)
+            hi, err2 := strconv.Atoi(to)
+            if err1 != nil || err2 != nil || hi < lo {
+                return nil, fmt.Errorf("invalid ID range %q", part)
+            }
+            if hi-lo >= maxIDListRange {
+                return nil, fmt.Errorf("ID range %q spans more than %d IDs", part, maxIDListRange)
+            }
+            for id := lo; id <= hi; id++ {
+                ids = append(ids, id)
+            }
+            continue
+        }
+        id, err := strconv.Atoi(part)
+        if err != nil {
+            return nil, fmt.Errorf("invalid talent ID %q", part)
+        }
+        ids = append(ids, id)
+    }
+    return ids, nil
+}
+
+// showRenumberDialog asks for the talents to renumber and the new start ID, then shows a dry-run report
+func showRenumberDialog(ctx *AppContext, selection []int) {
+    classMap, err := GetAllClasses(ctx)
+    if err != nil {
+        dialog.ShowError(err, ctx.Window)
+        return
+    }
+    tabs, err := GetAllTalentTabs(ctx)
+    if err != nil {
+        dialog.ShowError(err, ctx.Window)
+        return
+    }
+
+    scopeTab := tr("Current tab")
+    scopeClass := tr("Class")
+    scopeIDs := tr("Talent IDs")
+
+    var classNames []string
+    for _, c := range classMap {
+        classNames = append(classNames, c.NameENUS)
+    }
+    sort.Strings(classNames)
+    classSelect := widget.NewSelect(classNames, nil)
+
+    idsEntry := widget.NewEntry()
+    idsEntry.SetPlaceHolder(tr("e.g. 1001, 1005, 1010-1020"))
+    if len(selection) > 0 {
+        parts := make([]string, len(selection))
+        for i, id := range selection {
+            parts[i] = strconv.Itoa(id)
+        }
+        idsEntry.SetText(strings.Join(parts, ", "))
+    }
+
+    scope := widget.NewRadioGroup([]string{scopeTab, scopeClass, scopeIDs}, nil)
+    switch {
+    case len(selection) > 0:
+        scope.SetSelected(scopeIDs)
+    case ctx.CurrentTab != nil:
+        scope.SetSelected(scopeTab)
+    default:
+        scope.SetSelected(scopeClass)
+    }
+
+    startEntry := widget.NewEntry()
+    startEntry.SetPlaceHolder(tr("first new talent ID"))
+
+    items := []*widget.FormItem{
+        widget.NewFormItem(tr("Talents"), scope),
+        widget.NewFormItem(tr("Class"), classSelect),
+        widget.NewFormItem(tr("IDs"), idsEntry),
+        widget.NewFormItem(tr("New start ID"), startEntry),
+    }
+
+    dialog.ShowForm(tr("Renumber Talents"), tr("Preview"), tr("Cancel"), items, func(ok bool) {
+        if !ok {
+            return
+        }
+
+        all, err := loadAllTalents(ctx)
+        if err != nil {
+            dialog.ShowError(err, ctx.Window)
+            return
+        }
+
+        var selected []int
+        switch scope.Selected {
+        case scopeTab:
+            if ctx.CurrentTab == nil {
+                dialog.ShowInformation(tr("Renumber Talents"), tr("Select a TalentTab from the left first."), ctx.Window)
+                return
+            }
+            for _, t := range all {
+                if t.SpecID.Valid && int(t.SpecID.Int64) == ctx.CurrentTab.ID {
+                    selected = append(selected, t.ID)
+                }
+            }
+        case scopeClass:
+            tabIDs := make(map[int]bool)
+            for _, tab := range tabs {
+                if isPetTab(tab) {
+                    continue
+                }
+                for _, name := range tabClassNames(tab, classMap) {
+                    if name == classSelect.Selected {
+                        tabIDs[tab.ID] = true
+                    }
+                }
+            }
+            for _, t := range all {
+                if t.SpecID.Valid && tabIDs[int(t.SpecID.Int64)] {
+                    selected = append(selected, t.ID)
+                }
+            }
+        default:
+            selected, err = parseIDList(idsEntry.Text)
+            if err != nil {
+                dialog.ShowError(err, ctx.Window)
+                return
+            }
+        }
+
+        start, err := strconv.Atoi(strings.TrimSpace(startEntry.Text))
+        if err != nil {
+            dialog.ShowError(fmt.Errorf("invalid start ID %q", startEntry.Text), ctx.Window)
+            return
+        }
+
+        plan, err := planRenumber(all, selected, start)
+        if err != nil {
+            dialog.ShowError(err, ctx.Window)
+            return
+        }
+        showRenumberReport(ctx, plan)
+    }, ctx.Window)
+}
+
+// showRenumberReport shows the dry-run report of a plan and applies it on confirmation
+func showRenumberReport(ctx *AppContext, plan *RenumberPlan) {
+    report := widget.NewLabel(plan.Report())
+    report.TextStyle = fyne.TextStyle{Monospace: true}
+    scroll := container.NewVScroll(report)
+    scroll.SetMinSize(fyne.NewSize(500, 400))
+
+    confirm := dialog.NewCustomConfirm(tr("Renumber Talents - Preview"), tr("Apply"), tr("Cancel"), scroll, func(ok bool) {
+        if !ok {
+            return
+        }
+        if err := applyRenumber(ctx, plan); err != nil {
+            dialog.ShowError(err, ctx.Window)
+            return
+        }
+        reloadCurrentTab(ctx)
+    }, ctx.Window)
+    confirm.Show()
+}
@@ -0,0 +1,52 @@
+// Copyright (c) 2025 TalentEditor
+//
+// TalentEditor is licensed under the MIT License.
+// See the LICENSE file for details.
+
+package main
+
+import (
+    "reflect"
+    "testing"
+)
+
+func TestParseIDList(t *testing.T) {
+    tests := []struct {
+        in   string
+        want []int
+        ok   bool
+    }{
+        {"1, 5; 7", []int{1, 5, 7}, true},
+        {"10-13 20", []int{10, 11, 12, 13, 20}, true},
+        {"1-10000", nil, true},
+        {"1-10001", nil, false},
+        {"1-2000000000", nil, false},
+        {"5-3", nil, false},
+        {"x", nil, false},
+    }
+    for _, tt := range tests {
+        got, err := parseIDList(tt.in)
+        if (err == nil) != tt.ok {
+            t.Errorf("parseIDList(%q) error = %v, want ok %v", tt.in, err, tt.ok)
+            continue
+        }
+        if tt.want != nil && !reflect.DeepEqual(got, tt.want) {
+            t.Errorf("parseIDList(%q) = %v, want %v", tt.in, got, tt.want)
+        }
+    }
+}
+
+func TestRenumberClashes(t *testing.T) {
+    all := []Talent{{ID: 1}, {ID: 2}, {ID: 10}}
+    if _, err := planRenumber(all, []int{1, 2}, 9); err == nil {
+        t.Error("planRenumber onto a kept talent succeeded")
+    }
+    plan, err := planRenumber(all, []int{1, 2}, 2)
+    if err != nil {
+        t.Fatalf("planRenumber onto a moving talent failed: %v", err)
+    }
+    // A talent created at ID 3 after the preview
+    if err := plan.checkClashes(func(id int) bool { return id == 2 || id == 3 }); err == nil {
+        t.Error("checkClashes missed a talent created since the preview")
+    }
+}
@@ -0,0 +1,187 @@
+// Copyright (c) 2025 TalentEditor
+//
+// TalentEditor is licensed under the MIT License.
+// See the LICENSE file for details.
+
+package main
+
+import (
+    "fmt"
+    "image/color"
+    "sort"
+    "strconv"
+    "strings"
+
+    "fyne.io/fyne/v2"
+    "fyne.io/fyne/v2/container"
+    "fyne.io/fyne/v2/dialog"
+    "fyne.io/fyne/v2/widget"
+)
+
+var searchHighlightColor = color.NRGBA{R: 255, G: 215, B: 0, A: 255}
+
+// SearchResult is a talent matching a global search, with what matched
+type SearchResult struct {
+    Talent Talent
+    Match  string
+}
+
+// searchTalents finds talents by talent ID, by rank, required or prerequisite IDs,
+// or by the enUS or display locale name or description of their rank and required spells
+func searchTalents(all []Talent, spells map[int]Spell, query string) []SearchResult {
+    query = strings.TrimSpace(query)
+    if query == "" {
+        return nil
+    }
+    id, err := strconv.Atoi(query)
+    numeric := err == nil
+    text := strings.ToLower(query)
+
+    spellMatch := func(spellID int64) string {
+        spell, ok := spells[int(spellID)]
+        if !ok {
+            return ""
+        }
+        for _, name := range []string{spell.NameENUS, spell.LocalName} {
+            if name != "" && strings.Contains(strings.ToLower(name), text) {
+                return trf("name %q", name)
+            }
+        }
+        if strings.Contains(strings.ToLower(spell.Desc), text) || strings.Contains(strings.ToLower(spell.LocalDesc), text) {
+            return tr("description")
+        }
+        return ""
+    }
+
+    var results []SearchResult
+    for _, t := range all {
+        var matches []string
+        if numeric && t.ID == id {
+            matches = append(matches, tr("talent ID"))
+        }
+        for i, r := range t.Rank {
+            if !r.Valid || r.Int64 == 0 {
+                continue
+            }
+            if numeric && int(r.Int64) == id {
+                matches = append(matches, trf("rank %d spell", i+1))
+            } else if !numeric {
+                if m := spellMatch(r.Int64); m != "" {
+                    matches = append(matches, trf("rank %d spell %s", i+1, m))
+                }
+            }
+        }
+        if t.ReqSpellID.Valid && t.ReqSpellID.Int64 != 0 {
+            if numeric && int(t.ReqSpellID.Int64) == id {
+                matches = append(matches, tr("required spell"))
+            } else if !numeric {
+                if m := spellMatch(t.ReqSpellID.Int64); m != "" {
+                    matches = append(matches, trf("required spell %s", m))
+                }
+            }
+        }
+        if numeric {
+            for i, p := range t.PreReqTalent {
+                if p.Valid && p.Int64 != 0 && int(p.Int64) == id {
+                    matches = append(matches, trf("pre-requisite %d", i+1))
+                }
+            }
+        }
+
+        if len(matches) > 0 {
+            results = append(results, SearchResult{Talent: t, Match: strings.Join(matches, ", ")})
+        }
+    }
+    sort.Slice(results, func(i, j int) bool { return results[i].Talent.ID < results[j].Talent.ID })
+    return results
+}
+
+// searchSpellIDs returns the spell IDs of all ranks and the required spells of all talents
+func searchSpellIDs(all []Talent) []int {
+    ids := allRankSpellIDs(all)
+    for _, t := range all {
+        if t.ReqSpellID.Valid && t.ReqSpellID.Int64 > 0 {
+            ids = append(ids, int(t.ReqSpellID.Int64))
+        }
+    }
+    return ids
+}
+
+// jumpToTalent opens the tab of a talent and highlights its cell
+func jumpToTalent(ctx *AppContext, t Talent) {
+    tabs, err := GetAllTalentTabs(ctx)
+    if err != nil {
+        dialog.ShowError(err, ctx.Window)
+        return
+    }
+    tab, ok := tabs[int(t.SpecID.Int64)]
+    if !ok {
+        dialog.ShowError(fmt.Errorf("talent %d belongs to tab %d, which does not exist", t.ID, t.SpecID.Int64), ctx.Window)
+        return
+    }
+
+    loadTalentsForTab(ctx, tab)
+    if btn, ok := ctx.GridButtons[t.ID]; ok {
+        btn.SetHighlight(searchHighlightColor)
+    }
+    ctx.Window.RequestFocus()
+}
+
+// showSearchWindow opens the global talent search
+func showSearchWindow(ctx *AppContext) {
+    w := fyne.CurrentApp().NewWindow(tr("Search Talents"))
+
+    var results []SearchResult
+    var spells map[int]Spell
+    var tabs map[int]TalentTab
+    status := widget.NewLabel(tr("Search by talent ID, spell ID, spell name or description"))
+    list := widget.NewList(
+        func() int { return len(results) },
+        func() fyne.CanvasObject { return widget.NewLabel("") },
+        func(i widget.ListItemID, o fyne.CanvasObject) {
+            r := results[i]
+            tabName := fmt.Sprintf("tab_%d", r.Talent.SpecID.Int64)
+            if tab, ok := tabs[int(r.Talent.SpecID.Int64)]; ok {
+                tabName = tab.NameENUS
+            }
+            o.(*widget.Label).SetText(trf("%s (ID %d) in %s, tier %d, column %d - %s",
+                talentDisplayName(&r.Talent, spells), r.Talent.ID, tabName,
+                r.Talent.TierID.Int64+1, r.Talent.ColumnIndex.Int64+1, r.Match))
+        },
+    )
+    list.OnSelected = func(i widget.ListItemID) {
+        jumpToTalent(ctx, results[i].Talent)
+        list.UnselectAll()
+    }
+
+    entry := widget.NewEntry()
+    entry.SetPlaceHolder(tr("e.g. 1234, 12345 or Improved Fireball"))
+    entry.OnSubmitted = func(query string) {
+        // Search the current state, talents may have changed since the last search
+        all, err := loadAllTalents(ctx)
+        if err != nil {
+            dialog.ShowError(err, w)
+            return
+        }
+        if spells, err = GetSpellsByIDs(ctx, searchSpellIDs(all)); err != nil {
+            dialog.ShowError(err, w)
+            return
+        }
+        if tabs, err = GetAllTalentTabs(ctx); err != nil {
+            dialog.ShowError(err, w)
+            return
+        }
+        results = searchTalents(all, spells, query)
+        status.SetText(trn(len(results), "%d talent found", "%d talents found"))
+        list.Refresh()
+    }
+    searchBtn := widget.NewButton(tr("Search"), func() { entry.OnSubmitted(entry.Text) })
+
+    w.SetContent(container.NewBorder(
+        container.NewVBox(container.NewBorder(nil, nil, nil, searchBtn, entry), status),
+        nil, nil, nil, list,
+    ))
+    w.Resize(fyne.NewSize(700, 500))
+    w.Show()
+    w.Canvas().Focus(entry)
+}
@@ -0,0 +1,327 @@
+// Copyright (c) 2025 TalentEditor
+//
+// TalentEditor is licensed under the MIT License.
+// See the LICENSE file for details.
+
+package main
+
+import (
+    "database/sql"
+    "fmt"
+    "image/color"
+    "sort"
+    "strconv"
+    "strings"
+
+    "fyne.io/fyne/v2"
+    "fyne.io/fyne/v2/canvas"
+    "fyne.io/fyne/v2/container"
+    "fyne.io/fyne/v2/dialog"
+    "fyne.io/fyne/v2/layout"
+    "fyne.io/fyne/v2/widget"
+)
+
+var selectionColor = color.NRGBA{R: 0, G: 200, B: 255, A: 255}
+
+// TalentSelection is the set of talents selected in the current grid with
+// shift-click or box selection
+type TalentSelection struct {
+    TabID   int
+    Talents map[int]Talent
+}
+
+// selectedTalents returns the selected talents ordered by ID
+func selectedTalents(ctx *AppContext) []Talent {
+    if ctx.Selection == nil {
+        return nil
+    }
+    talents := make([]Talent, 0, len(ctx.Selection.Talents))
+    for _, t := range ctx.Selection.Talents {
+        talents = append(talents, t)
+    }
+    sort.Slice(talents, func(i, j int) bool { return talents[i].ID < talents[j].ID })
+    return talents
+}
+
+// isSelected reports whether the talent is part of the selection
+func isSelected(ctx *AppContext, talentID int) bool {
+    if ctx.Selection == nil {
+        return false
+    }
+    _, ok := ctx.Selection.Talents[talentID]
+    return ok
+}
+
+// selectTalent adds a talent to the selection, starting a new one when the tab changed
+func selectTalent(ctx *AppContext, tabID int, t Talent) {
+    if ctx.Selection == nil || ctx.Selection.TabID != tabID {
+        ctx.Selection = &TalentSelection{TabID: tabID, Talents: make(map[int]Talent)}
+    }
+    ctx.Selection.Talents[t.ID] = t
+}
+
+// toggleSelection adds or removes a talent from the selection
+func toggleSelection(ctx *AppContext, tab TalentTab, t Talent, reloadTab func()) {
+    if isSelected(ctx, t.ID) {
+        delete(ctx.Selection.Talents, t.ID)
+    } else {
+        selectTalent(ctx, tab.ID, t)
+    }
+    refreshSelection(ctx, tab, reloadTab)
+}
+
+// clearSelection empties the selection and removes its highlights
+func clearSelection(ctx *AppContext) {
+    if ctx.Selection == nil {
+        return
+    }
+    ctx.Selection = nil
+    for _, btn := range ctx.GridButtons {
+        btn.SetHighlight(nil)
+    }
+}
+
+// syncSelection keeps the selection of a freshly loaded tab in line with its talents
+func syncSelection(ctx *AppContext, tab TalentTab, talents []Talent) {
+    if ctx.Selection == nil {
+        return
+    }
+    if ctx.Selection.TabID != tab.ID {
+        ctx.Selection = nil
+        return
+    }
+    current := make(map[int]Talent, len(ctx.Selection.Talents))
+    for _, t := range talents {
+        if _, ok := ctx.Selection.Talents[t.ID]; ok {
+            current[t.ID] = t
+        }
+    }
+    ctx.Selection.Talents = current
+}
+
+// refreshSelection highlights the selected buttons and shows the bulk editor
+func refreshSelection(ctx *AppContext, tab TalentTab, reloadTab func()) {
+    if ctx.Selection != nil && len(ctx.Selection.Talents) == 0 {
+        ctx.Selection = nil
+    }
+    for id, btn := range ctx.GridButtons {
+        if isSelected(ctx, id) {
+            btn.SetHighlight(selectionColor)
+        } else {
+            btn.SetHighlight(nil)
+        }
+    }
+    if ctx.Selection != nil {
+        openBulkEditor(ctx, tab, reloadTab)
+    } else {
+        resetEditorContainer(ctx)
+    }
+}
+
+// attachBoxSelect lets the user drag a rectangle over the grid to select the talents
+// inside it. Holding shift adds to the current selection.
+func attachBoxSelect(ctx *AppContext, tab TalentTab, grid *fyne.Container, cells []*TalentButton, talents []Talent, reloadTab func()) {
+    box := canvas.NewRectangle(color.NRGBA{R: 0, G: 200, B: 255, A: 40})
+    box.StrokeColor = selectionColor
+    box.StrokeWidth = 1
+    box.Hide()
+    grid.Add(box)
+
+    byID := make(map[int]Talent, len(talents))
+    for _, t := range talents {
+        byID[t.ID] = t
+    }
+
+    var start, end fyne.Position
+    dragging := false
+    for _, cell := range cells {
+        cell := cell
+        cell.OnDragged = func(e *fyne.DragEvent) {
+            pos := cell.Position().Add(e.Position)
+            if !dragging {
+                dragging = true
+                start = pos.Subtract(e.Dragged)
+            }
+            end = pos
+            box.Move(fyne.NewPos(min(start.X, end.X), min(start.Y, end.Y)))
+            box.Resize(fyne.NewSize(abs32(end.X-start.X), abs32(end.Y-start.Y)))
+            box.Show()
+            box.Refresh()
+        }
+        cell.OnDragEnd = func() {
+            if !dragging {
+                return
+            }
+            dragging = false
+            box.Hide()
+
+            if cell.modifier&fyne.KeyModifierShift == 0 {
+                ctx.Selection = nil
+            }
+            minX, maxX := min(start.X, end.X), max(start.X, end.X)
+            minY, maxY := min(start.Y, end.Y), max(start.Y, end.Y)
+            for id, btn := range ctx.GridButtons {
+                p, s := btn.Position(), btn.Size()
+                if p.X+s.Width < minX || p.X > maxX || p.Y+s.Height < minY || p.Y > maxY {
+                    continue
+                }
+                if t, ok := byID[id]; ok {
+                    selectTalent(ctx, tab.ID, t)
+                }
+            }
+            refreshSelection(ctx, tab, reloadTab)
+        }
+    }
+}
+
+func abs32(v float32) float32 {
+    if v < 0 {
+        return -v
+    }
+    return v
+}
+
+// bulkField is a talent field that can be edited for the whole selection
+type bulkField struct {
+    Label string
+    Get   func(t *Talent) sql.NullInt64
+    Set   func(t *Talent, v sql.NullInt64)
+}
+
+var bulkFields = []bulkField{
+    {"Flags", func(t *Talent) sql.NullInt64 { return t.Flags }, func(t *Talent, v sql.NullInt64) { t.Flags = v }},
+    {"Required Spell ID", func(t *Talent) sql.NullInt64 { return t.ReqSpellID }, func(t *Talent, v sql.NullInt64) { t.ReqSpellID = v }},
+    {"Allow for Pet Flags 1", func(t *Talent) sql.NullInt64 { return t.AllowForPetFlags1 }, func(t *Talent, v sql.NullInt64) { t.AllowForPetFlags1 = v }},
+    {"Allow for Pet Flags 2", func(t *Talent) sql.NullInt64 { return t.AllowForPetFlags2 }, func(t *Talent, v sql.NullInt64) { t.AllowForPetFlags2 = v }},
+}
+
+// openBulkEditor shows the editor for the fields shared by all selected talents.
+// Fields that differ across the selection are shown as "mixed" and stay untouched
+// unless a new value is entered.
+func openBulkEditor(ctx *AppContext, tab TalentTab, reloadTab func()) {
+    talents := selectedTalents(ctx)
+    ctx.EditorContainer.Objects = nil
+
+    ids := make([]string, len(talents))
+    for i, t := range talents {
+        ids[i] = strconv.Itoa(t.ID)
+    }
+    header := widget.NewLabel(trn(len(talents), "%d talent selected\nIDs: %s", "%d talents selected\nIDs: %s", strings.Join(ids, ", ")))
+    header.Wrapping = fyne.TextWrapWord
+
+    entries := make([]*widget.Entry, len(bulkFields))
+    initial := make([]string, len(bulkFields))
+    var formItems []*widget.FormItem
+    for i, f := range bulkFields {
+        e := widget.NewEntry()
+        value := nullIntString(f.Get(&talents[0]))
+        for _, t := range talents[1:] {
+            if nullIntString(f.Get(&t)) != value {
+                value = ""
+                e.SetPlaceHolder(tr("mixed"))
+                break
+            }
+        }
+        e.SetText(value)
+        if ctx.ReadOnly {
+            e.Disable()
+        }
+        entries[i], initial[i] = e, value
+
+        lbl := widget.NewLabel(tr(f.Label))
+        formItems = append(formItems, &widget.FormItem{Widget: container.New(layout.NewGridLayout(2), lbl, e)})
+    }
+
+    options, tabIDs, err := tabOptions(ctx)
+    if err != nil {
+        dialog.ShowError(err, ctx.Window)
+        return
+    }
+    tabSelect := widget.NewSelect(options, nil)
+    tabSelect.PlaceHolder = tr("(move to tab)")
+
+    applyBtn := widget.NewButton(tr("Apply"), func() {
+        changes := make(map[int]sql.NullInt64)
+        for i, e := range entries {
+            text := strings.TrimSpace(e.Text)
+            if text == initial[i] {
+                continue
+            }
+            n, err := strconv.ParseInt(text, 10, 64)
+            if err != nil {
+                dialog.ShowError(fmt.Errorf("invalid %s %q", bulkFields[i].Label, e.Text), ctx.Window)
+                return
+            }
+            changes[i] = sql.NullInt64{Int64: n, Valid: true}
+        }
+        if len(changes) == 0 {
+            return
+        }
+        if err := bulkUpdateTalents(ctx, talents, changes); err != nil {
+            dialog.ShowError(err, ctx.Window)
+            return
+        }
+        reloadTab()
+    })
+    moveBtn := widget.NewButton(tr("Move"), func() {
+        targetID, ok := tabIDs[tabSelect.Selected]
+        if !ok || targetID == tab.ID {
+            return
+        }
+        minTier, minCol := talentsAnchor(talents)
+        if err := pasteTalents(ctx, &TalentClipboard{Talents: talents, Cut: true}, targetID, minTier, minCol); err != nil {
+            dialog.ShowError(err, ctx.Window)
+            return
+        }
+        clearSelection(ctx)
+        reloadTab()
+    })
+    clearBtn := widget.NewButton(tr("Clear Selection"), func() {
+        clearSelection(ctx)
+        resetEditorContainer(ctx)
+    })
+    if ctx.ReadOnly {
+        applyBtn.Disable()
+        moveBtn.Disable()
+        tabSelect.Disable()
+    }
+
+    form := widget.NewForm(formItems...)
+    top := container.NewVBox(header, form, container.NewBorder(nil, nil, nil, moveBtn, tabSelect))
+    btnRow := NewEditorButtonRow(container.NewHBox(applyBtn, clearBtn), nil)
+
+    ctx.EditorContainer.Objects = []fyne.CanvasObject{
+        container.NewBorder(top, btnRow, nil, nil, container.NewMax()),
+    }
+    ctx.EditorContainer.Refresh()
+}
+
+// bulkUpdateTalents sets the changed fields, by bulkFields index, on all talents in one transaction
+func bulkUpdateTalents(ctx *AppContext, talents []Talent, changes map[int]sql.NullInt64) error {
+    all, err := loadAllTalents(ctx)
+    if err != nil {
+        return err
+    }
+    current := make(map[int]Talent, len(all))
+    for _, t := range all {
+        current[t.ID] = t
+    }
+
+    return runWrite(ctx, func(w *WriteTx) error {
+        for _, sel := range talents {
+            t, ok := current[sel.ID]
+            if !ok {
+                return fmt.Errorf("talent %d no longer exists", sel.ID)
+            }
+            for i, v := range changes {
+                bulkFields[i].Set(&t, v)
+            }
+            query, args := UpdateTalentQuery(&t)
+            if _, err := w.Exec(query, args...); err != nil {
+                return err
+            }
+            w.TrackTalent(t.ID, &t)
+        }
+        return nil
+    })
+}
@@ -0,0 +1,103 @@
+// Copyright (c) 2025 TalentEditor
+//
+// TalentEditor is licensed under the MIT License.
+// See the LICENSE file for details.
+
+package main
+
+import (
+    "encoding/json"
+    "fmt"
+    "io"
+    "time"
+)
+
+// TalentData is a complete, in-memory copy of the talent data of one source,
+// either loaded from a database connection or from a snapshot file
+type TalentData struct {
+    Name    string
+    Created time.Time
+    Tabs    map[int]TalentTab
+    Talents []Talent
+    Spells  map[int]Spell // first-rank spells, used for names and icons
+}
+
+// loadTalentData reads all tabs, talents and their first-rank spells from the context database
+func loadTalentData(ctx *AppContext, name string) (*TalentData, error) {
+    tabs, err := GetAllTalentTabs(ctx)
+    if err != nil {
+        return nil, err
+    }
+
+    talents, err := GetAllTalents(ctx)
+    if err != nil {
+        return nil, err
+    }
+
+    spells, err := GetSpellsByIDs(ctx, firstRankSpellIDs(talents))
+    if err != nil {
+        return nil, err
+    }
+
+    return &TalentData{
+        Name:    name,
+        Created: time.Now(),
+        Tabs:    tabs,
+        Talents: talents,
+        Spells:  spells,
+    }, nil
+}
+
+// withPendingChanges returns a copy of the data with the pending dry-run talent states applied
+func (d *TalentData) withPendingChanges(ctx *AppContext, patch *SQLPatch, name string) (*TalentData, error) {
+    talents := patch.OverlayTalents(d.Talents, func(t *Talent) bool { return true })
+
+    spells, err := GetSpellsByIDs(ctx, firstRankSpellIDs(talents))
+    if err != nil {
+        return nil, err
+    }
+
+    return &TalentData{
+        Name:    name,
+        Created: time.Now(),
+        Tabs:    d.Tabs,
+        Talents: talents,
+        Spells:  spells,
+    }, nil
+}
+
+// TalentsForTab returns the talents belonging to the given tab
+func (d *TalentData) TalentsForTab(tabID int) []Talent {
+    var talents []Talent
+    for _, t := range d.Talents {
+        if t.SpecID.Valid && int(t.SpecID.Int64) == tabID {
+            talents = append(talents, t)
+        }
+    }
+    return talents
+}
+
+// writeSnapshot stores the talent data as indented JSON
+func writeSnapshot(w io.Writer, data *TalentData) error {
+    enc := json.NewEncoder(w)
+    enc.SetIndent("", "  ")
+    if err := enc.Encode(data); err != nil {
+        return fmt.Errorf("encode snapshot: %w", err)
+    }
+    return nil
+}
+
+// readSnapshot loads talent data previously stored with writeSnapshot
+func readSnapshot(r io.Reader) (*TalentData, error) {
+    var data TalentData
+    if err := json.NewDecoder(r).Decode(&data); err != nil {
+        return nil, fmt.Errorf("decode snapshot: %w", err)
+    }
+    if data.Tabs == nil {
+        data.Tabs = make(map[int]TalentTab)
+    }
+    if data.Spells == nil {
+        data.Spells = make(map[int]Spell)
+    }
+    return &data, nil
+}
@@ -0,0 +1,456 @@
+// Copyright (c) 2025 TalentEditor
+//
+// TalentEditor is licensed under the MIT License.
+// See the LICENSE file for details.
+
+package main
+
+import (
+    "fmt"
+    "math"
+    "regexp"
+    "strconv"
+    "strings"
+)
+
+// SpellEffects holds the Spell columns referenced by description tokens
+type SpellEffects struct {
+    ID             int
+    Exists         bool // false when there is no Spell row with this ID
+    BasePoints     [3]int64
+    DieSides       [3]int64
+    Amplitude      [3]int64 // tick period in ms
+    RadiusIndex    [3]int64
+    ChainTargets   [3]int64
+    MiscValue      [3]int64
+    PointsPerCombo [3]float64
+    Multiplier     [3]float64
+    DurationIndex  int64
+    ProcChance     int64
+    ProcCharges    int64
+    StackAmount    int64
+    MaxTargetLevel int64
+    MaxTargets     int64
+}
+
+// SpellDuration is a row of the SpellDuration table, durations in ms
+type SpellDuration struct {
+    ID       int
+    Duration int64
+    PerLevel int64
+    Max      int64
+}
+
+// SpellRadius is a row of the SpellRadius table, radii in yards
+type SpellRadius struct {
+    ID       int
+    Radius   float64
+    PerLevel float64
+    Max      float64
+}
+
+// spellDescData resolves the spell data used by description tokens
+type spellDescData struct {
+    Effects   func(spellID int) (SpellEffects, bool)
+    Durations map[int]SpellDuration
+    Radii     map[int]SpellRadius
+}
+
+// spellRefPattern matches cross-spell references such as $12345s1 and $/10;12345s1
+var spellRefPattern = regexp.MustCompile(`\$(?:[/*][0-9.]+;)?([0-9]+)[a-zA-Z]`)
+
+// referencedSpellIDs returns the IDs of other spells used by a description
+func referencedSpellIDs(desc string) []int {
+    var ids []int
+    for _, m := range spellRefPattern.FindAllStringSubmatch(desc, -1) {
+        if id, err := strconv.Atoi(m[1]); err == nil {
+            ids = append(ids, id)
+        }
+    }
+    return ids
+}
+
+// loadSpellDescData prefetches the effects of the spells and returns the data to render
+// their descriptions. Fails when the effect columns cannot be read.
+func loadSpellDescData(ctx *AppContext, ids []int) (spellDescData, error) {
+    if _, err := GetSpellEffects(ctx, ids); err != nil {
+        return spellDescData{}, err
+    }
+
+    // Durations and radii are optional, their tokens stay unrendered without them
+    durations, _ := GetSpellDurations(ctx)
+    radii, _ := GetSpellRadii(ctx)
+
+    return spellDescData{
+        Effects: func(id int) (SpellEffects, bool) {
+            effects, err := GetSpellEffects(ctx, []int{id})
+            if err != nil {
+                return SpellEffects{}, false
+            }
+            e := effects[id]
+            return e, e.Exists
+        },
+        Durations: durations,
+        Radii:     radii,
+    }, nil
+}
+
+// spellDescriber renders spell descriptions with the effect data of the database.
+// When the effect columns cannot be read the raw descriptions are returned.
+func spellDescriber(ctx *AppContext, spells map[int]Spell) func(Spell) string {
+    var ids []int
+    for id, spell := range spells {
+        ids = append(ids, id)
+        ids = append(ids, referencedSpellIDs(spell.DisplayDesc())...)
+    }
+    data, err := loadSpellDescData(ctx, ids)
+    if err != nil {
+        return func(s Spell) string { return s.DisplayDesc() }
+    }
+    return func(s Spell) string { return renderSpellDesc(s.DisplayDesc(), s.ID, data) }
+}
+
+// renderSpellDesc replaces the tokens of the 3.3.5 description grammar with values:
+//
+//  $s1 $m1 $M1 $o1 $t1 $a1 $x1 $e1 $b1 $q1  effect values of effect 1..3
+//  $d $h $n $u $v $i $z                      duration, proc chance/charges, stacks, level, targets, home
+//  $12345s1                                  the same tokens on another spell
+//  $/10;s1 $*2;s1                            divided or multiplied token values
+//  ${$m1*2+5}                                arithmetic
+//  $lsingular:plural; $gmale:female;         grammar
+//
+// Tokens that cannot be resolved are kept as they are.
+func renderSpellDesc(desc string, spellID int, data spellDescData) string {
+    r := &descRenderer{data: data, self: spellID}
+    var sb strings.Builder
+    for i := 0; i < len(desc); {
+        if desc[i] != '$' {
+            sb.WriteByte(desc[i])
+            i++
+            continue
+        }
+        text, n := r.token(desc[i:])
+        if n == 0 {
+            sb.WriteByte('$')
+            i++
+            continue
+        }
+        sb.WriteString(text)
+        i += n
+    }
+    return sb.String()
+}
+
+type descRenderer struct {
+    data       spellDescData
+    self       int
+    lastNumber float64 // last rendered number, for $l
+}
+
+// token renders the token at the start of s, which starts with '$'.
+// Returns the number of bytes consumed, 0 if there is no valid token.
+func (r *descRenderer) token(s string) (string, int) {
+    if len(s) < 2 {
+        return "", 0
+    }
+
+    switch c := s[1]; {
+    case c == '{':
+        end := strings.IndexByte(s, '}')
+        if end < 0 {
+            return "", 0
+        }
+        v, ok := r.evalExpr(s[2:end])
+        if !ok {
+            return "", 0
+        }
+        r.lastNumber = v
+        return formatDescNumber(v), end + 1
+
+    case c == '/' || c == '*':
+        semi := strings.IndexByte(s, ';')
+        if semi < 0 {
+            return "", 0
+        }
+        factor, err := strconv.ParseFloat(s[2:semi], 64)
+        if err != nil || factor == 0 {
+            return "", 0
+        }
+        _, v, n, ok := r.value("$" + s[semi+1:])
+        if !ok {
+            return "", 0
+        }
+        if c == '/' {
+            v /= factor
+        } else {
+            v *= factor
+        }
+        r.lastNumber = v
+        return formatDescNumber(v), semi + n
+
+    case c == 'l' || c == 'L' || c == 'g' || c == 'G':
+        semi := strings.IndexByte(s, ';')
+        if semi < 0 {
+            return "", 0
+        }
+        forms := strings.Split(s[2:semi], ":")
+        text := forms[0]
+        if (c == 'l' || c == 'L') && r.lastNumber != 1 {
+            text = forms[len(forms)-1]
+        }
+        return text, semi + 1
+    }
+
+    text, v, n, ok := r.value(s)
+    if !ok {
+        return "", 0
+    }
+    r.lastNumber = v
+    return text, n
+}
+
+// value resolves a value token like $s1 or $12345d at the start of s, returning
+// its display text, its numeric value and the number of bytes consumed
+func (r *descRenderer) value(s string) (string, float64, int, bool) {
+    i := 1
+    spellID := r.self
+    for i < len(s) && s[i] >= '0' && s[i] <= '9' {
+        i++
+    }
+    if i > 1 {
+        id, err := strconv.Atoi(s[1:i])
+        if err != nil {
+            return "", 0, 0, false
+        }
+        spellID = id
+    }
+    if i >= len(s) {
+        return "", 0, 0, false
+    }
+    letter := s[i]
+    i++
+
+    effect := 0
+    if i < len(s) && s[i] >= '1' && s[i] <= '3' {
+        effect = int(s[i] - '1')
+        i++
+    }
+
+    if letter == 'z' || letter == 'Z' {
+        return "<Home>", 0, i, true
+    }
+
+    e, ok := r.data.Effects(spellID)
+    if !ok {
+        return "", 0, 0, false
+    }
+
+    number := func(v float64) (string, float64, int, bool) {
+        return formatDescNumber(v), v, i, true
+    }
+
+    minValue := float64(e.BasePoints[effect])
+    if e.DieSides[effect] > 0 {
+        minValue++
+    }
+    maxValue := float64(e.BasePoints[effect] + e.DieSides[effect])
+    minValue, maxValue = math.Abs(minValue), math.Abs(maxValue)
+    if minValue > maxValue {
+        minValue, maxValue = maxValue, minValue
+    }
+
+    switch letter {
+    case 's', 'S':
+        if maxValue > minValue {
+            return fmt.Sprintf("%s to %s", formatDescNumber(minValue), formatDescNumber(maxValue)), minValue, i, true
+        }
+        return number(minValue)
+    case 'm':
+        return number(minValue)
+    case 'M':
+        return number(maxValue)
+    case 'o', 'O':
+        duration, ok := r.duration(e)
+        if !ok || e.Amplitude[effect] <= 0 || duration <= 0 {
+            return number(minValue)
+        }
+        return number(minValue * float64(duration/e.Amplitude[effect]))
+    case 't', 'T':
+        return number(float64(e.Amplitude[effect]) / 1000)
+    case 'd', 'D':
+        duration, ok := r.duration(e)
+        if !ok {
+            return "", 0, 0, false
+        }
+        return formatDescDuration(duration), float64(duration) / 1000, i, true
+    case 'a', 'A':
+        radius, ok := r.data.Radii[int(e.RadiusIndex[effect])]
+        if !ok {
+            return "", 0, 0, false
+        }
+        return number(radius.Radius)
+    case 'x', 'X':
+        return number(float64(e.ChainTargets[effect]))
+    case 'e', 'E':
+        return number(e.Multiplier[effect])
+    case 'b', 'B':
+        return number(math.Abs(e.PointsPerCombo[effect]))
+    case 'q', 'Q':
+        return number(float64(e.MiscValue[effect]))
+    case 'h', 'H':
+        return number(float64(e.ProcChance))
+    case 'n', 'N':
+        return number(float64(e.ProcCharges))
+    case 'u', 'U':
+        return number(float64(e.StackAmount))
+    case 'v', 'V':
+        return number(float64(e.MaxTargetLevel))
+    case 'i', 'I':
+        return number(float64(e.MaxTargets))
+    }
+    return "", 0, 0, false
+}
+
+// duration returns the base duration of a spell in ms, negative for infinite
+func (r *descRenderer) duration(e SpellEffects) (int64, bool) {
+    d, ok := r.data.Durations[int(e.DurationIndex)]
+    if !ok {
+        return 0, false
+    }
+    return d.Duration, true
+}
+
+// evalExpr evaluates the arithmetic of a ${...} token
+func (r *descRenderer) evalExpr(expr string) (float64, bool) {
+    p := &exprParser{s: expr, r: r}
+    v, ok := p.sum()
+    p.skipSpace()
+    if !ok || p.pos != len(p.s) {
+        return 0, false
+    }
+    return v, true
+}
+
+// exprParser is a recursive descent parser for + - * / ( ) over numbers, value tokens
+// and named variables
+type exprParser struct {
+    s    string
+    pos  int
+    r    *descRenderer      // nil when value tokens are not allowed
+    vars map[string]float64 // nil when variables are not allowed
+}
+
+func (p *exprParser) skipSpace() {
+    for p.pos < len(p.s) && p.s[p.pos] == ' ' {
+        p.pos++
+    }
+}
+
+func (p *exprParser) sum() (float64, bool) {
+    v, ok := p.product()
+    for ok {
+        p.skipSpace()
+        if p.pos >= len(p.s) || (p.s[p.pos] != '+' && p.s[p.pos] != '-') {
+            break
+        }
+        op := p.s[p.pos]
+        p.pos++
+        var rhs float64
+        rhs, ok = p.product()
+        if op == '+' {
+            v += rhs
+        } else {
+            v -= rhs
+        }
+    }
+    return v, ok
+}
+
+func (p *exprParser) product() (float64, bool) {
+    v, ok := p.unary()
+    for ok {
+        p.skipSpace()
+        if p.pos >= len(p.s) || (p.s[p.pos] != '*' && p.s[p.pos] != '/') {
+            break
+        }
+        op := p.s[p.pos]
+        p.pos++
+        var rhs float64
+        rhs, ok = p.unary()
+        if op == '*' {
+            v *= rhs
+        } else if rhs != 0 {
+            v /= rhs
+        } else {
+            ok = false
+        }
+    }
+    return v, ok
+}
+
+func (p *exprParser) unary() (float64, bool) {
+    p.skipSpace()
+    if p.pos >= len(p.s) {
+        return 0, false
+    }
+    switch c := p.s[p.pos]; {
+    case c == '-':
+        p.pos++
+        v, ok := p.unary()
+        return -v, ok
+    case c == '(':
+        p.pos++
+        v, ok := p.sum()
+        p.skipSpace()
+        if !ok || p.pos >= len(p.s) || p.s[p.pos] != ')' {
+            return 0, false
+        }
+        p.pos++
+        return v, true
+    case c == '$':
+        if p.r == nil {
+            return 0, false
+        }
+        _, v, n, ok := p.r.value(p.s[p.pos:])
+        p.pos += n
+        return v, ok
+    case c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z':
+        start := p.pos
+        for p.pos < len(p.s) && (p.s[p.pos] >= 'a' && p.s[p.pos] <= 'z' || p.s[p.pos] >= 'A' && p.s[p.pos] <= 'Z') {
+            p.pos++
+        }
+        v, ok := p.vars[strings.ToLower(p.s[start:p.pos])]
+        return v, ok
+    case c >= '0' && c <= '9' || c == '.':
+        start := p.pos
+        for p.pos < len(p.s) && (p.s[p.pos] >= '0' && p.s[p.pos] <= '9' || p.s[p.pos] == '.') {
+            p.pos++
+        }
+        v, err := strconv.ParseFloat(p.s[start:p.pos], 64)
+        return v, err == nil
+    }
+    return 0, false
+}
+
+// formatDescNumber prints whole numbers without decimals and others with up to two
+func formatDescNumber(v float64) string {
+    return strconv.FormatFloat(math.Round(v*100)/100, 'f', -1, 64)
+}
+
+// formatDescDuration prints a duration in ms the way the client does
+func formatDescDuration(ms int64) string {
+    switch {
+    case ms < 0:
+        return "until cancelled"
+    case ms >= 3600000:
+        hours := float64(ms) / 3600000
+        if hours == 1 {
+            return "1 hour"
+        }
+        return formatDescNumber(hours) + " hours"
+    case ms >= 60000:
+        return formatDescNumber(float64(ms)/60000) + " min"
+    default:
+        return formatDescNumber(float64(ms)/1000) + " sec"
+    }
+}
@@ -0,0 +1,109 @@
+// Copyright (c) 2025 TalentEditor
+//
+// TalentEditor is licensed under the MIT License.
+// See the LICENSE file for details.
+
+package main
+
+import "testing"
+
+// testDescData has spell 100 with a 10 to 20 damage effect ticking every 3 sec for 12 sec,
+// a 5 yard radius and 3 chain targets, and spell 12345 with a flat 30
+func testDescData() spellDescData {
+    effects := map[int]SpellEffects{
+        100: {
+            ID: 100, Exists: true,
+            BasePoints:    [3]int64{9, 5, 0},
+            DieSides:      [3]int64{11, 0, 0},
+            Amplitude:     [3]int64{3000, 0, 0},
+            RadiusIndex:   [3]int64{8, 0, 0},
+            ChainTargets:  [3]int64{3, 0, 0},
+            DurationIndex: 5,
+            ProcChance:    25,
+        },
+        12345: {ID: 12345, Exists: true, BasePoints: [3]int64{29, 0, 0}, DieSides: [3]int64{1, 0, 0}, DurationIndex: 6},
+    }
+    return spellDescData{
+        Effects: func(id int) (SpellEffects, bool) {
+            e, ok := effects[id]
+            return e, ok
+        },
+        Durations: map[int]SpellDuration{5: {ID: 5, Duration: 12000}, 6: {ID: 6, Duration: 1800000}},
+        Radii:     map[int]SpellRadius{8: {ID: 8, Radius: 5}},
+    }
+}
+
+func TestRenderSpellDesc(t *testing.T) {
+    tests := []struct {
+        desc string
+        want string
+    }{
+        {"Deals $s1 damage.", "Deals 10 to 20 damage."},
+        {"Increases by $s2%.", "Increases by 5%."},
+        {"$m1 to $M1", "10 to 20"},
+        {"$o1 over $d.", "40 over 12 sec."},
+        {"Every $t1 sec", "Every 3 sec"},
+        {"within $a1 yards, $x1 targets, $h% chance", "within 5 yards, 3 targets, 25% chance"},
+        {"Copies $12345s1 for $12345d.", "Copies 30 for 30 min."},
+        {"$/10;12345s1 per tick", "3 per tick"},
+        {"$*2;s2 total", "10 total"},
+        {"${$m1*2+5} damage", "25 damage"},
+        {"${($12345m1-10)/4} stacks", "5 stacks"},
+        {"$s2 $lpoint:points;", "5 points"},
+        {"${$s2/5} $lpoint:points;", "1 point"},
+        {"$gher:his; weapon", "her weapon"},
+        {"Returns to $z.", "Returns to <Home>."},
+        {"Unknown $99999s1 and $", "Unknown $99999s1 and $"},
+        {"Broken ${$m1*} keeps its braces", "Broken ${10*} keeps its braces"},
+    }
+    data := testDescData()
+    for _, tt := range tests {
+        if got := renderSpellDesc(tt.desc, 100, data); got != tt.want {
+            t.Errorf("renderSpellDesc(%q) = %q, want %q", tt.desc, got, tt.want)
+        }
+    }
+}
+
+func TestEvalExpr(t *testing.T) {
+    tests := []struct {
+        expr string
+        want float64
+        ok   bool
+    }{
+        {"1+2*3", 7, true},
+        {"(1+2)*3", 9, true},
+        {"-4/2", -2, true},
+        {" 2.5 * 2 ", 5, true},
+        {"$m1+$12345m1", 40, true},
+        {"1/0", 0, false},
+        {"2+", 0, false},
+        {"(1", 0, false},
+        {"r", 0, false},
+    }
+    r := &descRenderer{data: testDescData(), self: 100}
+    for _, tt := range tests {
+        got, ok := r.evalExpr(tt.expr)
+        if ok != tt.ok || (ok && got != tt.want) {
+            t.Errorf("evalExpr(%q) = %v, %v, want %v, %v", tt.expr, got, ok, tt.want, tt.ok)
+        }
+    }
+}
+
+func TestFormatDescDuration(t *testing.T) {
+    tests := []struct {
+        ms   int64
+        want string
+    }{
+        {-1, "until cancelled"},
+        {1500, "1.5 sec"},
+        {30000, "30 sec"},
+        {90000, "1.5 min"},
+        {3600000, "1 hour"},
+        {7200000, "2 hours"},
+    }
+    for _, tt := range tests {
+        if got := formatDescDuration(tt.ms); got != tt.want {
+            t.Errorf("formatDescDuration(%d) = %q, want %q", tt.ms, got, tt.want)
+        }
+    }
+}
@@ -0,0 +1,222 @@
+// Copyright (c) 2025 TalentEditor
+//
+// TalentEditor is licensed under the MIT License.
+// See the LICENSE file for details.
+
+package main
+
+import (
+    "database/sql"
+    "fmt"
+    "strconv"
+    "strings"
+
+    "fyne.io/fyne/v2"
+    "fyne.io/fyne/v2/canvas"
+    "fyne.io/fyne/v2/container"
+    "fyne.io/fyne/v2/dialog"
+    "fyne.io/fyne/v2/theme"
+    "fyne.io/fyne/v2/widget"
+)
+
+// SpellEdit holds the columns of a rank spell changed by the spell editor
+type SpellEdit struct {
+    ID         int
+    Name       string
+    Desc       string
+    Tooltip    string
+    IconID     int64
+    BasePoints [3]int64
+}
+
+// updateSpell writes a spell and refreshes the spell caches. In dry-run mode the caches
+// keep the pending values so the grid shows them until the patch is applied or discarded.
+func updateSpell(ctx *AppContext, e *SpellEdit) error {
+    err := runWrite(ctx, func(w *WriteTx) error {
+        query, args := UpdateSpellQuery(e)
+        _, err := w.Exec(query, args...)
+        return err
+    })
+    if err != nil {
+        return err
+    }
+
+    if ctx.Patch == nil {
+        delete(ctx.Spells, e.ID)
+        delete(ctx.SpellEffects, e.ID)
+        return nil
+    }
+    if ctx.Spells == nil {
+        ctx.Spells = make(map[int]Spell)
+    }
+    ctx.Spells[e.ID] = Spell{ID: e.ID, NameENUS: e.Name, IconID: sql.NullInt64{Int64: e.IconID, Valid: true}, Desc: e.Desc}
+    if effects, ok := ctx.SpellEffects[e.ID]; ok {
+        effects.BasePoints = e.BasePoints
+        ctx.SpellEffects[e.ID] = effects
+    }
+    return nil
+}
+
+// invalidateSpellCaches drops all cached spell data, e.g. after pending spell edits were discarded.
+// SpellIcon rows are included as the icon picker caches the rows it creates in dry-run mode.
+func invalidateSpellCaches(ctx *AppContext) {
+    ctx.Spells = nil
+    ctx.SpellEffects = nil
+    ctx.SpellIcons = nil
+}
+
+// showSpellEditor edits the name, texts, icon and effect base points of a rank spell
+func showSpellEditor(ctx *AppContext, spellID int, onSaved func()) {
+    if spellID <= 0 {
+        dialog.ShowInformation(tr("Spell Editor"), tr("Enter a rank spell ID first."), ctx.Window)
+        return
+    }
+    edit, err := GetSpellForEdit(ctx, spellID)
+    if err != nil {
+        dialog.ShowError(err, ctx.Window)
+        return
+    }
+    iconIDs, err := GetAllSpellIcons(ctx)
+    if err != nil {
+        dialog.ShowError(err, ctx.Window)
+        return
+    }
+
+    nameEntry := widget.NewEntry()
+    nameEntry.SetText(edit.Name)
+    descEntry := widget.NewMultiLineEntry()
+    descEntry.Wrapping = fyne.TextWrapWord
+    descEntry.SetText(edit.Desc)
+    tooltipEntry := widget.NewMultiLineEntry()
+    tooltipEntry.Wrapping = fyne.TextWrapWord
+    tooltipEntry.SetText(edit.Tooltip)
+
+    iconEntry := widget.NewEntry()
+    iconEntry.SetText(strconv.FormatInt(edit.IconID, 10))
+    iconImage := canvas.NewImageFromResource(theme.BrokenImageIcon())
+    iconImage.FillMode = canvas.ImageFillContain
+    iconImage.SetMinSize(fyne.NewSize(36, 36))
+    showIcon := func(text string) {
+        iconImage.Resource = theme.BrokenImageIcon()
+        if id, err := strconv.Atoi(strings.TrimSpace(text)); err == nil {
+            if res := spellIconResource(iconIDs, id); res != nil {
+                iconImage.Resource = res
+            }
+        }
+        iconImage.Refresh()
+    }
+    iconEntry.OnChanged = showIcon
+    showIcon(iconEntry.Text)
+    // The enUS texts saved with all locales replace the ones in this form, so saving the
+    // form afterwards does not write the old texts back
+    translationsBtn := widget.NewButton(tr("All Locales..."), func() {
+        showSpellTranslations(ctx, spellID, func(texts map[string]SpellText) {
+            enUS := texts[defaultLocale]
+            nameEntry.SetText(enUS.Name)
+            descEntry.SetText(enUS.Desc)
+            tooltipEntry.SetText(enUS.Tooltip)
+            if onSaved != nil {
+                onSaved()
+            }
+        })
+    })
+    pickBtn := widget.NewButton(tr("Pick..."), func() {
+        current, _ := strconv.Atoi(strings.TrimSpace(iconEntry.Text))
+        showIconPicker(ctx, trf("Icon - %s", edit.Name), current, func(iconID int) {
+            iconEntry.SetText(strconv.Itoa(iconID))
+        })
+    })
+
+    baseEntries := make([]*widget.Entry, 3)
+    for i := range baseEntries {
+        baseEntries[i] = widget.NewEntry()
+        baseEntries[i].SetText(strconv.FormatInt(edit.BasePoints[i], 10))
+    }
+
+    // Live preview of the rendered description with the edited base points
+    preview := widget.NewLabel("")
+    preview.Wrapping = fyne.TextWrapWord
+    data, dataErr := loadSpellDescData(ctx, append([]int{spellID}, referencedSpellIDs(edit.Desc)...))
+    updatePreview := func(string) {
+        if dataErr != nil {
+            preview.SetText(tr("(effect data unavailable)"))
+            return
+        }
+        effects := data.Effects
+        override := data
+        override.Effects = func(id int) (SpellEffects, bool) {
+            e, ok := effects(id)
+            if id == spellID && ok {
+                for i, entry := range baseEntries {
+                    if v, err := strconv.ParseInt(strings.TrimSpace(entry.Text), 10, 64); err == nil {
+                        e.BasePoints[i] = v
+                    }
+                }
+            }
+            return e, ok
+        }
+        preview.SetText(renderSpellDesc(descEntry.Text, spellID, override))
+    }
+    descEntry.OnChanged = updatePreview
+    for _, e := range baseEntries {
+        e.OnChanged = updatePreview
+    }
+    updatePreview("")
+
+    items := []*widget.FormItem{
+        widget.NewFormItem(tr("Name"), container.NewBorder(nil, nil, nil, translationsBtn, nameEntry)),
+        widget.NewFormItem(tr("Description"), descEntry),
+        widget.NewFormItem(tr("Preview"), preview),
+        widget.NewFormItem(tr("Tooltip"), tooltipEntry),
+        widget.NewFormItem(tr("Icon ID"), container.NewBorder(nil, nil, nil, container.NewHBox(iconImage, pickBtn), iconEntry)),
+    }
+    for i, e := range baseEntries {
+        items = append(items, widget.NewFormItem(trf("Effect %d base points", i+1), e))
+    }
+    form := widget.NewForm(items...)
+    scroll := container.NewVScroll(form)
+    scroll.SetMinSize(fyne.NewSize(550, 500))
+
+    title := trf("Spell %d - %s", edit.ID, edit.Name)
+    if ctx.ReadOnly {
+        for _, e := range append([]*widget.Entry{nameEntry, descEntry, tooltipEntry, iconEntry}, baseEntries...) {
+            e.Disable()
+        }
+        pickBtn.Disable()
+        dialog.ShowCustom(title, tr("Close"), scroll, ctx.Window)
+        return
+    }
+
+    dialog.ShowCustomConfirm(title, tr("Save"), tr("Cancel"), scroll, func(ok bool) {
+        if !ok {
+            return
+        }
+
+        updated := *edit
+        updated.Name = nameEntry.Text
+        updated.Desc = descEntry.Text
+        updated.Tooltip = tooltipEntry.Text
+        iconID, err := strconv.ParseInt(strings.TrimSpace(iconEntry.Text), 10, 64)
+        if err != nil {
+            dialog.ShowError(fmt.Errorf("invalid icon ID %q", iconEntry.Text), ctx.Window)
+            return
+        }
+        updated.IconID = iconID
+        for i, e := range baseEntries {
+            v, err := strconv.ParseInt(strings.TrimSpace(e.Text), 10, 64)
+            if err != nil {
+                dialog.ShowError(fmt.Errorf("invalid base points %q for effect %d", e.Text, i+1), ctx.Window)
+                return
+            }
+            updated.BasePoints[i] = v
+        }
+
+        if err := updateSpell(ctx, &updated); err != nil {
+            dialog.ShowError(err, ctx.Window)
+            return
+        }
+        if onSaved != nil {
+            onSaved()
+        }
+    }, ctx.Window)
+}
@@ -0,0 +1,414 @@
+// Copyright (c) 2025 TalentEditor
+//
+// TalentEditor is licensed under the MIT License.
+// See the LICENSE file for details.
+
+package main
+
+import (
+    "database/sql"
+    "database/sql/driver"
+    "encoding/hex"
+    "errors"
+    "fmt"
+    "io"
+    "sort"
+    "strconv"
+    "strings"
+    "time"
+)
+
+// errReadOnly is returned by every write path while the application runs in read-only mode
+var errReadOnly = errors.New("the database is opened in read-only mode, changes are not allowed")
+
+// SQLPatch collects rendered write statements in dry-run mode instead of executing them.
+// Talents tracks the latest pending state of every written talent so the grid and the
+// patch notes can show the pending changes on top of the database.
+type SQLPatch struct {
+    Statements []string
+    Talents    map[int]*Talent  // nil value = pending delete
+    MaxIDs     map[string]int64 // highest ID created by the patch, by table
+}
+
+func NewSQLPatch() *SQLPatch {
+    return &SQLPatch{Talents: make(map[int]*Talent), MaxIDs: make(map[string]int64)}
+}
+
+// Exec renders the statement with its parameters and appends it to the patch
+func (p *SQLPatch) Exec(query string, args ...interface{}) (sql.Result, error) {
+    stmt, err := renderSQL(query, args)
+    if err != nil {
+        return nil, err
+    }
+    p.Statements = append(p.Statements, stmt)
+    return driver.RowsAffected(1), nil
+}
+
+// TrackTalent records the pending state of a talent, nil for a deleted talent
+func (p *SQLPatch) TrackTalent(id int, t *Talent) {
+    if t == nil {
+        p.Talents[id] = nil
+        return
+    }
+    copied := *t
+    p.Talents[id] = &copied
+}
+
+// Append moves the statements and talent states of another patch into this one
+func (p *SQLPatch) Append(other *SQLPatch) {
+    p.Statements = append(p.Statements, other.Statements...)
+    for id, t := range other.Talents {
+        p.Talents[id] = t
+    }
+    for table, id := range other.MaxIDs {
+        p.MaxIDs[table] = max(p.MaxIDs[table], id)
+    }
+}
+
+// MaxTalentID returns the highest talent ID written by the patch
+func (p *SQLPatch) MaxTalentID() int64 {
+    var maxID int64
+    for id := range p.Talents {
+        if int64(id) > maxID {
+            maxID = int64(id)
+        }
+    }
+    return maxID
+}
+
+// OverlayTalents applies the pending talent states to talents read from the database.
+// Pending talents are only added when keep returns true, e.g. to filter by tab.
+func (p *SQLPatch) OverlayTalents(talents []Talent, keep func(t *Talent) bool) []Talent {
+    var result []Talent
+    for _, t := range talents {
+        if _, pending := p.Talents[t.ID]; !pending {
+            result = append(result, t)
+        }
+    }
+
+    ids := make([]int, 0, len(p.Talents))
+    for id, t := range p.Talents {
+        if t != nil && keep(t) {
+            ids = append(ids, id)
+        }
+    }
+    sort.Ints(ids)
+    for _, id := range ids {
+        result = append(result, *p.Talents[id])
+    }
+    return result
+}
+
+// Export writes the patch as a .sql file wrapped in a single transaction
+func (p *SQLPatch) Export(w io.Writer, dbName string) (int64, error) {
+    var sb strings.Builder
+    sb.WriteString("-- TalentEditor SQL patch\n")
+    fmt.Fprintf(&sb, "-- Database: %s\n", dbName)
+    fmt.Fprintf(&sb, "-- Generated: %s\n", time.Now().Format("2006-01-02 15:04:05"))
+    fmt.Fprintf(&sb, "-- Statements: %d\n\n", len(p.Statements))
+    sb.WriteString("START TRANSACTION;\n\n")
+    for _, stmt := range p.Statements {
+        sb.WriteString(stmt)
+        sb.WriteString(";\n\n")
+    }
+    sb.WriteString("COMMIT;\n")
+
+    n, err := io.WriteString(w, sb.String())
+    return int64(n), err
+}
+
+// renderSQL substitutes the ? placeholders of a query with SQL literals
+func renderSQL(query string, args []interface{}) (string, error) {
+    var sb strings.Builder
+    argIndex := 0
+    var quote rune
+
+    for _, r := range strings.TrimSpace(query) {
+        switch {
+        case quote != 0:
+            if r == quote {
+                quote = 0
+            }
+            sb.WriteRune(r)
+        case r == '\'' || r == '"' || r == '`':
+            quote = r
+            sb.WriteRune(r)
+        case r == '?':
+            if argIndex >= len(args) {
+                return "", fmt.Errorf("render sql: not enough arguments for query")
+            }
+            literal, err := sqlLiteral(args[argIndex])
+            if err != nil {
+                return "", err
+            }
+            sb.WriteString(literal)
+            argIndex++
+        default:
+            sb.WriteRune(r)
+        }
+    }
+
+    if argIndex != len(args) {
+        return "", fmt.Errorf("render sql: %d arguments for %d placeholders", len(args), argIndex)
+    }
+    return sb.String(), nil
+}
+
+// sqlLiteral formats a query parameter as a MySQL literal
+func sqlLiteral(v interface{}) (string, error) {
+    switch x := v.(type) {
+    case nil:
+        return "NULL", nil
+    case int:
+        return strconv.Itoa(x), nil
+    case int32:
+        return strconv.FormatInt(int64(x), 10), nil
+    case int64:
+        return strconv.FormatInt(x, 10), nil
+    case uint32:
+        return strconv.FormatUint(uint64(x), 10), nil
+    case uint64:
+        return strconv.FormatUint(x, 10), nil
+    case float32:
+        return strconv.FormatFloat(float64(x), 'g', -1, 32), nil
+    case float64:
+        return strconv.FormatFloat(x, 'g', -1, 64), nil
+    case bool:
+        if x {
+            return "1", nil
+        }
+        return "0", nil
+    case string:
+        return quoteSQLString(x), nil
+    case []byte:
+        return "X'" + hex.EncodeToString(x) + "'", nil
+    case time.Time:
+        return "'" + x.Format("2006-01-02 15:04:05") + "'", nil
+    case sql.NullInt64:
+        return sqlLiteral(nullInt64ToInterface(x))
+    case sql.NullString:
+        if !x.Valid {
+            return "NULL", nil
+        }
+        return quoteSQLString(x.String), nil
+    default:
+        return "", fmt.Errorf("render sql: unsupported argument type %T", v)
+    }
+}
+
+func quoteSQLString(s string) string {
+    replacer := strings.NewReplacer(
+        `\`, `\\`,
+        `'`, `\'`,
+        "\x00", `\0`,
+        "\n", `\n`,
+        "\r", `\r`,
+        "\x1a", `\Z`,
+    )
+    return "'" + replacer.Replace(s) + "'"
+}
+
+// splitSQLStatements splits a .sql file into statements on semicolons outside of
+// quotes and comments. Transaction control statements are dropped because the
+// patch is always applied inside its own transaction.
+func splitSQLStatements(script string) []string {
+    var statements []string
+    var sb strings.Builder
+    var quote byte
+
+    flush := func() {
+        stmt := strings.TrimSpace(sb.String())
+        sb.Reset()
+        if stmt == "" {
+            return
+        }
+        switch strings.ToUpper(strings.Join(strings.Fields(stmt), " ")) {
+        case "START TRANSACTION", "BEGIN", "COMMIT", "ROLLBACK":
+            return
+        }
+        statements = append(statements, stmt)
+    }
+
+    for i := 0; i < len(script); i++ {
+        c := script[i]
+        switch {
+        case quote != 0:
+            sb.WriteByte(c)
+            if c == '\\' && quote != '`' && i+1 < len(script) {
+                i++
+                sb.WriteByte(script[i])
+            } else if c == quote {
+                quote = 0
+            }
+        case c == '\'' || c == '"' || c == '`':
+            quote = c
+            sb.WriteByte(c)
+        case c == '-' && strings.HasPrefix(script[i:], "-- "), c == '#':
+            // Skip line comment
+            for i < len(script) && script[i] != '\n' {
+                i++
+            }
+            sb.WriteByte('\n')
+        case c == '/' && strings.HasPrefix(script[i:], "/*"):
+            end := strings.Index(script[i+2:], "*/")
+            if end < 0 {
+                i = len(script)
+            } else {
+                i += end + 3
+            }
+        case c == ';':
+            flush()
+        default:
+            sb.WriteByte(c)
+        }
+    }
+    flush()
+
+    return statements
+}
+
+// applySQLPatch executes all statements of a .sql patch in one transaction and rolls back on the first error
+func applySQLPatch(ctx *AppContext, script string) (int, error) {
+    if ctx.ReadOnly {
+        return 0, errReadOnly
+    }
+
+    statements := splitSQLStatements(script)
+    if len(statements) == 0 {
+        return 0, fmt.Errorf("patch contains no statements")
+    }
+
+    tx, err := ctx.DB.Begin()
+    if err != nil {
+        return 0, fmt.Errorf("begin transaction: %w", err)
+    }
+
+    for i, stmt := range statements {
+        if _, err := execWithDebug(ctx, tx, stmt); err != nil {
+            tx.Rollback()
+            return 0, fmt.Errorf("statement %d failed, patch rolled back: %w", i+1, err)
+        }
+    }
+
+    if err := tx.Commit(); err != nil {
+        return 0, fmt.Errorf("commit: %w", err)
+    }
+    return len(statements), nil
+}
+
+// WriteTx is the target of a write operation: a database transaction, or the
+// pending SQL patch when the application runs in dry-run mode
+type WriteTx struct {
+    ctx     *AppContext
+    tx      *sql.Tx
+    db      *sql.DB
+    staged  *SQLPatch
+    pending *SQLPatch
+}
+
+// runWrite runs fn inside a single transaction. In dry-run mode the statements are
+// collected into the pending patch instead, and only once fn succeeds. Read-only mode
+// is refused here before a transaction is opened, and again by execWithDebug.
+func runWrite(ctx *AppContext, fn func(w *WriteTx) error) error {
+    if ctx.ReadOnly {
+        return errReadOnly
+    }
+
+    if ctx.Patch != nil {
+        w := &WriteTx{ctx: ctx, db: ctx.DB, staged: NewSQLPatch(), pending: ctx.Patch}
+        if err := fn(w); err != nil {
+            return err
+        }
+        ctx.Patch.Append(w.staged)
+        updateWindowTitle(ctx)
+        return nil
+    }
+
+    tx, err := ctx.DB.Begin()
+    if err != nil {
+        return fmt.Errorf("begin transaction: %w", err)
+    }
+    if err := fn(&WriteTx{ctx: ctx, tx: tx, db: ctx.DB}); err != nil {
+        tx.Rollback()
+        return err
+    }
+    return tx.Commit()
+}
+
+// Exec runs a write statement, or appends it to the patch in dry-run mode
+func (w *WriteTx) Exec(query string, args ...interface{}) (sql.Result, error) {
+    if w.staged != nil {
+        return w.staged.Exec(query, args...)
+    }
+    return execWithDebug(w.ctx, w.tx, query, args...)
+}
+
+// QueryRow reads inside the transaction, or from the database in dry-run mode
+func (w *WriteTx) QueryRow(query string, args ...interface{}) *sql.Row {
+    if w.tx != nil {
+        return w.tx.QueryRow(query, args...)
+    }
+    return w.db.QueryRow(query, args...)
+}
+
+// Query reads rows inside the transaction, or from the database in dry-run mode
+func (w *WriteTx) Query(query string, args ...interface{}) (*sql.Rows, error) {
+    if w.tx != nil {
+        return queryWithDebug(w.tx, query, args...)
+    }
+    return queryWithDebug(w.db, query, args...)
+}
+
+// DryRun reports whether statements are collected instead of executed
+func (w *WriteTx) DryRun() bool {
+    return w.staged != nil
+}
+
+// TrackTalent records the new state of a written talent for the dry-run overlay
+func (w *WriteTx) TrackTalent(id int, t *Talent) {
+    if w.staged != nil {
+        w.staged.TrackTalent(id, t)
+    }
+}
+
+// TrackID records a row created in dry-run mode in a table with allocated IDs, so the ID
+// is not handed out twice
+func (w *WriteTx) TrackID(table string, id int) {
+    if w.staged != nil && int64(id) > w.staged.MaxIDs[table] {
+        w.staged.MaxIDs[table] = int64(id)
+    }
+}
+
+// MaxPendingID returns the highest ID of a table created but not yet applied in dry-run mode
+func (w *WriteTx) MaxPendingID(table string) int64 {
+    if w.staged == nil {
+        return 0
+    }
+    return max(w.staged.MaxIDs[table], w.pending.MaxIDs[table])
+}
+
+// PendingTalentIDs returns the talent IDs written but not yet applied in dry-run mode
+func (w *WriteTx) PendingTalentIDs() []int {
+    if w.staged == nil {
+        return nil
+    }
+    var ids []int
+    for _, p := range []*SQLPatch{w.staged, w.pending} {
+        for id := range p.Talents {
+            ids = append(ids, id)
+        }
+    }
+    return ids
+}
+
+// MaxPendingTalentID returns the highest talent ID written but not yet applied in dry-run mode
+func (w *WriteTx) MaxPendingTalentID() int64 {
+    if w.staged == nil {
+        return 0
+    }
+    maxID := w.staged.MaxTalentID()
+    if pending := w.pending.MaxTalentID(); pending > maxID {
+        maxID = pending
+    }
+    return maxID
+}
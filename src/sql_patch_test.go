@@ -0,0 +1,131 @@
+// Copyright (c) 2025 TalentEditor
+//
+// TalentEditor is licensed under the MIT License.
+// See the LICENSE file for details.
+
+package main
+
+import (
+    "database/sql"
+    "errors"
+    "reflect"
+    "strings"
+    "testing"
+)
+
+func TestRenderSQL(t *testing.T) {
+    tests := []struct {
+        query string
+        args  []interface{}
+        want  string
+    }{
+        {"UPDATE Talent SET tier_id = ? WHERE id = ?", []interface{}{2, int64(100)}, "UPDATE Talent SET tier_id = 2 WHERE id = 100"},
+        {"UPDATE Spell SET spell_name_enus = ?", []interface{}{"Nature's Grasp"}, `UPDATE Spell SET spell_name_enus = 'Nature\'s Grasp'`},
+        {"SELECT ?", []interface{}{`C:\path`}, `SELECT 'C:\\path'`},
+        {"SELECT ?", []interface{}{"a;b\nc"}, `SELECT 'a;b\nc'`},
+        {"SELECT ?, ?", []interface{}{nil, sql.NullInt64{}}, "SELECT NULL, NULL"},
+        {"SELECT ?", []interface{}{sql.NullInt64{Int64: 7, Valid: true}}, "SELECT 7"},
+        {"SELECT ?", []interface{}{sql.NullString{String: "x", Valid: true}}, "SELECT 'x'"},
+        {"SELECT ?", []interface{}{[]byte{0x01, 0xAB}}, "SELECT X'01ab'"},
+        {"SELECT ?, ?", []interface{}{true, 1.5}, "SELECT 1, 1.5"},
+        {"SELECT '?', `a?`, ?", []interface{}{3}, "SELECT '?', `a?`, 3"},
+        {"  SELECT 1  ", nil, "SELECT 1"},
+    }
+    for _, tt := range tests {
+        got, err := renderSQL(tt.query, tt.args)
+        if err != nil {
+            t.Errorf("renderSQL(%q) failed: %v", tt.query, err)
+            continue
+        }
+        if got != tt.want {
+            t.Errorf("renderSQL(%q) = %q, want %q", tt.query, got, tt.want)
+        }
+    }
+
+    for _, tt := range []struct {
+        query string
+        args  []interface{}
+    }{
+        {"SELECT ?, ?", []interface{}{1}},
+        {"SELECT ?", []interface{}{1, 2}},
+        {"SELECT ?", []interface{}{struct{}{}}},
+    } {
+        if _, err := renderSQL(tt.query, tt.args); err == nil {
+            t.Errorf("renderSQL(%q, %v) succeeded, want an error", tt.query, tt.args)
+        }
+    }
+}
+
+func TestQuoteSQLString(t *testing.T) {
+    tests := []struct {
+        in   string
+        want string
+    }{
+        {"", "''"},
+        {"it's", `'it\'s'`},
+        {`back\slash`, `'back\\slash'`},
+        {`\'`, `'\\\''`},
+        {"a\x00b\rc\x1a", `'a\0b\rc\Z'`},
+        {`"double"`, `'"double"'`},
+    }
+    for _, tt := range tests {
+        if got := quoteSQLString(tt.in); got != tt.want {
+            t.Errorf("quoteSQLString(%q) = %s, want %s", tt.in, got, tt.want)
+        }
+    }
+}
+
+func TestSplitSQLStatements(t *testing.T) {
+    tests := []struct {
+        name   string
+        script string
+        want   []string
+    }{
+        {"plain", "UPDATE a SET b = 1; UPDATE c SET d = 2;", []string{"UPDATE a SET b = 1", "UPDATE c SET d = 2"}},
+        {"no trailing semicolon", "SELECT 1", []string{"SELECT 1"}},
+        {"semicolon in strings", `UPDATE s SET n = 'a;b', m = "c;d", x = ` + "`e;f`;", []string{`UPDATE s SET n = 'a;b', m = "c;d", x = ` + "`e;f`"}},
+        {"escaped quotes", `UPDATE s SET n = 'it\'s; here', m = 'back\\';`, []string{`UPDATE s SET n = 'it\'s; here', m = 'back\\'`}},
+        {"line comments", "-- header; not a statement\nSELECT 1; # trailing; comment\nSELECT 2;", []string{"SELECT 1", "SELECT 2"}},
+        {"comment markers in strings", "UPDATE s SET n = '-- no # comment';", []string{"UPDATE s SET n = '-- no # comment'"}},
+        {"block comment", "/* a; b */ SELECT 1;", []string{"SELECT 1"}},
+        {"transaction control", "START TRANSACTION;\nBEGIN;\nSELECT 1;\ncommit;\nROLLBACK;", []string{"SELECT 1"}},
+        {"empty statements", ";;  ;\n", nil},
+    }
+    for _, tt := range tests {
+        if got := splitSQLStatements(tt.script); !reflect.DeepEqual(got, tt.want) {
+            t.Errorf("%s: splitSQLStatements = %q, want %q", tt.name, got, tt.want)
+        }
+    }
+}
+
+func TestSQLPatchRoundTrip(t *testing.T) {
+    p := NewSQLPatch()
+    if _, err := p.Exec("UPDATE Spell SET spell_name_enus = ?, spell_desc_enus = ? WHERE id = ?",
+        "Nature's; Grasp", "Line 1\nLine 2 -- not a comment", 100); err != nil {
+        t.Fatal(err)
+    }
+    if _, err := p.Exec("DELETE FROM Talent WHERE id = ?", 7); err != nil {
+        t.Fatal(err)
+    }
+
+    var sb strings.Builder
+    if _, err := p.Export(&sb, "dbc"); err != nil {
+        t.Fatal(err)
+    }
+    if got := splitSQLStatements(sb.String()); !reflect.DeepEqual(got, p.Statements) {
+        t.Errorf("exported patch splits into %q, want %q", got, p.Statements)
+    }
+}
+
+func TestExecReadOnly(t *testing.T) {
+    p := NewSQLPatch()
+    if _, err := execWithDebug(&AppContext{ReadOnly: true}, p, "DELETE FROM Talent WHERE id = ?", 7); !errors.Is(err, errReadOnly) {
+        t.Errorf("execWithDebug in read-only mode = %v, want errReadOnly", err)
+    }
+    if len(p.Statements) != 0 {
+        t.Errorf("read-only exec reached the target: %q", p.Statements)
+    }
+    if err := runWrite(&AppContext{ReadOnly: true}, func(w *WriteTx) error { return nil }); !errors.Is(err, errReadOnly) {
+        t.Errorf("runWrite in read-only mode = %v, want errReadOnly", err)
+    }
+}
@@ -0,0 +1,251 @@
+// Copyright (c) 2025 TalentEditor
+//
+// TalentEditor is licensed under the MIT License.
+// See the LICENSE file for details.
+
+package main
+
+import (
+    "fmt"
+    "sort"
+    "strings"
+
+    "fyne.io/fyne/v2"
+    "fyne.io/fyne/v2/canvas"
+    "fyne.io/fyne/v2/container"
+    "fyne.io/fyne/v2/widget"
+)
+
+const tabIconSize = 20
+
+// tabNode is a row of the tab browser, either a class/pet group or a tab
+type tabNode struct {
+    Label  string
+    Icon   fyne.Resource
+    Tab    *TalentTab // nil for groups
+    Search string     // lower case text matched by the filter
+}
+
+// tabTree is the content of the tab browser, keyed by tree node ID
+type tabTree struct {
+    Nodes    map[widget.TreeNodeID]*tabNode
+    Children map[widget.TreeNodeID][]widget.TreeNodeID
+}
+
+// tabBrowserData is everything the tab browser shows besides the tabs themselves
+type tabBrowserData struct {
+    Classes      map[int]ChrClass
+    Families     map[int]CreatureFamily // empty without CreatureFamily data
+    TalentCounts map[int]int            // by tab ID
+    TalentNames  map[int][]string       // lower case enUS and localized names of the talents of each tab
+    IconIDs      map[int]string
+    Locale       string // display locale of the tab names
+}
+
+// buildTabTree groups the tabs by class in OrderIndex order, and the pet tabs by
+// creature family under a "Pets" group. Tabs shared by several classes appear under each.
+func buildTabTree(tabs map[int]TalentTab, data tabBrowserData) *tabTree {
+    tree := &tabTree{
+        Nodes:    make(map[widget.TreeNodeID]*tabNode),
+        Children: make(map[widget.TreeNodeID][]widget.TreeNodeID),
+    }
+
+    classTabs := make(map[int][]TalentTab)
+    var unknownTabs, petTabs []TalentTab
+    for _, t := range tabs {
+        if isPetTab(t) {
+            petTabs = append(petTabs, t)
+            continue
+        }
+        matched := false
+        for _, c := range data.Classes {
+            if c.ID >= 1 && c.ID <= 32 && t.ClassMask.Int64&(1<<(c.ID-1)) != 0 {
+                classTabs[c.ID] = append(classTabs[c.ID], t)
+                matched = true
+            }
+        }
+        if !matched {
+            unknownTabs = append(unknownTabs, t)
+        }
+    }
+
+    addTabs := func(groupID widget.TreeNodeID, tabs []TalentTab, extra func(t TalentTab) string) {
+        for _, t := range tabs {
+            tab := t
+            id := fmt.Sprintf("%s/tab:%d", groupID, t.ID)
+            name := tabName(t, data.Locale)
+            label := fmt.Sprintf("%s (%d)", name, data.TalentCounts[t.ID])
+            search := strings.ToLower(t.NameENUS+"\n"+name) + "\n" + strings.Join(data.TalentNames[t.ID], "\n")
+            if extra != nil {
+                if e := extra(t); e != "" {
+                    label += " - " + e
+                    search += "\n" + strings.ToLower(e)
+                }
+            }
+            tree.Nodes[id] = &tabNode{
+                Label:  label,
+                Icon:   spellIconResource(data.IconIDs, int(t.SpellIcon.Int64)),
+                Tab:    &tab,
+                Search: search,
+            }
+            tree.Children[groupID] = append(tree.Children[groupID], id)
+        }
+    }
+    byOrder := func(tabs []TalentTab) {
+        sort.Slice(tabs, func(i, j int) bool {
+            if tabs[i].OrderIndex.Int64 != tabs[j].OrderIndex.Int64 {
+                return tabs[i].OrderIndex.Int64 < tabs[j].OrderIndex.Int64
+            }
+            return tabs[i].ID < tabs[j].ID
+        })
+    }
+
+    for _, c := range sortedClasses(data.Classes) {
+        if len(classTabs[c.ID]) == 0 {
+            continue
+        }
+        groupID := fmt.Sprintf("class:%d", c.ID)
+        tree.Nodes[groupID] = &tabNode{
+            Label:  c.NameENUS,
+            Icon:   iconResourceByName("ClassIcon_" + strings.ReplaceAll(c.NameENUS, " ", "")),
+            Search: strings.ToLower(c.NameENUS),
+        }
+        tree.Children[""] = append(tree.Children[""], groupID)
+        byOrder(classTabs[c.ID])
+        addTabs(groupID, classTabs[c.ID], nil)
+    }
+
+    if len(unknownTabs) > 0 {
+        tree.Nodes["unknown"] = &tabNode{Label: tr("Unknown"), Search: strings.ToLower(tr("Unknown"))}
+        tree.Children[""] = append(tree.Children[""], "unknown")
+        byOrder(unknownTabs)
+        addTabs("unknown", unknownTabs, nil)
+    }
+
+    if len(petTabs) > 0 {
+        tree.Nodes["pets"] = &tabNode{
+            Label:  tr("Pets"),
+            Icon:   iconResourceByName("Ability_Hunter_BeastTaming"),
+            Search: strings.ToLower(tr("Pets")),
+        }
+        tree.Children[""] = append(tree.Children[""], "pets")
+        sort.Slice(petTabs, func(i, j int) bool {
+            if petTabs[i].CreatureFamily.Int64 != petTabs[j].CreatureFamily.Int64 {
+                return petTabs[i].CreatureFamily.Int64 < petTabs[j].CreatureFamily.Int64
+            }
+            return petTabs[i].OrderIndex.Int64 < petTabs[j].OrderIndex.Int64
+        })
+        addTabs("pets", petTabs, func(t TalentTab) string {
+            return petTabFamilies(t, data.Families)
+        })
+    }
+    return tree
+}
+
+// petTabFamilies names the creature families whose pet talent type is in the tab's mask
+func petTabFamilies(t TalentTab, families map[int]CreatureFamily) string {
+    var names []string
+    for _, f := range families {
+        if f.NameENUS != "" && f.PetTalentType >= 0 && t.CreatureFamily.Int64&(1<<f.PetTalentType) != 0 {
+            names = append(names, f.NameENUS)
+        }
+    }
+    sort.Strings(names)
+    if len(names) > 3 {
+        return trf("%s, %s, %s and %d more", names[0], names[1], names[2], len(names)-3)
+    }
+    return strings.Join(names, ", ")
+}
+
+// filter keeps the groups matching the query with all their tabs, and the tabs
+// matching by tab or talent name within other groups
+func (t *tabTree) filter(query string) *tabTree {
+    query = strings.ToLower(strings.TrimSpace(query))
+    if query == "" {
+        return t
+    }
+
+    filtered := &tabTree{Nodes: t.Nodes, Children: make(map[widget.TreeNodeID][]widget.TreeNodeID)}
+    for _, groupID := range t.Children[""] {
+        groupMatch := strings.Contains(t.Nodes[groupID].Search, query)
+        var tabs []widget.TreeNodeID
+        for _, id := range t.Children[groupID] {
+            if groupMatch || strings.Contains(t.Nodes[id].Search, query) {
+                tabs = append(tabs, id)
+            }
+        }
+        if len(tabs) > 0 {
+            filtered.Children[""] = append(filtered.Children[""], groupID)
+            filtered.Children[groupID] = tabs
+        }
+    }
+    return filtered
+}
+
+// loadTabBrowserData collects the talent counts, talent names, classes and icons for the tab browser
+func loadTabBrowserData(ctx *AppContext) (tabBrowserData, error) {
+    data := tabBrowserData{Locale: ctx.Locale}
+    var err error
+
+    if data.Classes, err = GetAllClasses(ctx); err != nil {
+        return data, err
+    }
+    if data.IconIDs, err = GetAllSpellIcons(ctx); err != nil {
+        return data, err
+    }
+    // CreatureFamily is optional, pet tabs are listed without family names
+    if data.Families, err = GetAllCreatureFamilies(ctx); err != nil {
+        data.Families = nil
+    }
+
+    talents, err := loadAllTalents(ctx)
+    if err != nil {
+        return data, err
+    }
+    var firstRanks []int
+    for _, t := range talents {
+        if t.Rank[0].Valid && t.Rank[0].Int64 > 0 {
+            firstRanks = append(firstRanks, int(t.Rank[0].Int64))
+        }
+    }
+    spells, err := GetSpellsByIDs(ctx, firstRanks)
+    if err != nil {
+        return data, err
+    }
+
+    data.TalentCounts = make(map[int]int)
+    data.TalentNames = make(map[int][]string)
+    for i := range talents {
+        tabID := int(talents[i].SpecID.Int64)
+        data.TalentCounts[tabID]++
+        // Both names so the filter finds talents by their enUS and their localized name
+        name, local := talentName(&talents[i], spells), talentDisplayName(&talents[i], spells)
+        data.TalentNames[tabID] = append(data.TalentNames[tabID], strings.ToLower(name))
+        if local != name {
+            data.TalentNames[tabID] = append(data.TalentNames[tabID], strings.ToLower(local))
+        }
+    }
+    return data, nil
+}
+
+// newTabTreeNode creates a tab browser row, an icon followed by a label
+func newTabTreeNode() fyne.CanvasObject {
+    icon := canvas.NewImageFromResource(nil)
+    icon.FillMode = canvas.ImageFillContain
+    icon.SetMinSize(fyne.NewSize(tabIconSize, tabIconSize))
+    return container.NewHBox(icon, widget.NewLabel(""))
+}
+
+// updateTabTreeNode shows a node in a row created by newTabTreeNode
+func updateTabTreeNode(node *tabNode, o fyne.CanvasObject) {
+    row := o.(*fyne.Container)
+    icon := row.Objects[0].(*canvas.Image)
+    icon.Resource = node.Icon
+    if node.Icon == nil {
+        icon.Hide()
+    } else {
+        icon.Show()
+    }
+    icon.Refresh()
+    row.Objects[1].(*widget.Label).SetText(node.Label)
+}
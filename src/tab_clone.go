@@ -0,0 +1,166 @@
+// Copyright (c) 2025 TalentEditor
+//
+// TalentEditor is licensed under the MIT License.
+// See the LICENSE file for details.
+
+package main
+
+import (
+    "database/sql"
+    "fmt"
+    "strconv"
+    "strings"
+
+    "fyne.io/fyne/v2/dialog"
+    "fyne.io/fyne/v2/widget"
+)
+
+// CloneTabOptions are the values of the new tab created by cloneTalentTab
+type CloneTabOptions struct {
+    Name       string
+    ClassMask  int64
+    OrderIndex int64
+    KeepSpells bool // false leaves all rank spells blank
+}
+
+// cloneTalentTab creates a new TalentTab row as a copy of src, together with copies of
+// all its talents, in one transaction. Returns the ID of the new tab.
+func cloneTalentTab(ctx *AppContext, src TalentTab, opts CloneTabOptions) (int, error) {
+    all, err := loadAllTalents(ctx)
+    if err != nil {
+        return 0, err
+    }
+
+    var talents []Talent
+    for _, t := range all {
+        if t.SpecID.Valid && int(t.SpecID.Int64) == src.ID {
+            talents = append(talents, t)
+        }
+    }
+
+    var newTabID int
+    err = runWrite(ctx, func(w *WriteTx) error {
+        var maxID int64
+        if err := w.QueryRow("SELECT COALESCE(MAX(id), 0) FROM TalentTab FOR UPDATE").Scan(&maxID); err != nil {
+            return err
+        }
+        if pending := w.MaxPendingID("TalentTab"); pending > maxID {
+            maxID = pending
+        }
+        newTabID = int(maxID + 1)
+
+        // Copy the whole row so columns the editor does not know about are kept. A rollback
+        // does not drop temporary tables, so one left on the pooled connection by a failed
+        // clone is dropped first.
+        cloneQuery, cloneArgs := CloneTabQuery(newTabID, opts)
+        statements := []struct {
+            query string
+            args  []interface{}
+        }{
+            {"DROP TEMPORARY TABLE IF EXISTS talent_tab_clone", nil},
+            {"CREATE TEMPORARY TABLE talent_tab_clone SELECT * FROM TalentTab WHERE id = ?", []interface{}{src.ID}},
+            {cloneQuery, cloneArgs},
+            {"INSERT INTO TalentTab SELECT * FROM talent_tab_clone", nil},
+            {"DROP TEMPORARY TABLE talent_tab_clone", nil},
+        }
+        for _, stmt := range statements {
+            if _, err := w.Exec(stmt.query, stmt.args...); err != nil {
+                return idInUseError(err, "talent tab", newTabID)
+            }
+        }
+        w.TrackID("TalentTab", newTabID)
+
+        if len(talents) == 0 {
+            return nil
+        }
+
+        minTier, minCol := talentsAnchor(talents)
+        placed, err := placeTalents(talents, nil, newTabID, minTier, minCol, false)
+        if err != nil {
+            return err
+        }
+        for i := range placed {
+            if !opts.KeepSpells {
+                for r := range placed[i].Rank {
+                    placed[i].Rank[r] = sql.NullInt64{Int64: 0, Valid: true}
+                }
+            }
+        }
+
+        ids, err := allocateTalentIDs(ctx, w, len(placed))
+        if err != nil {
+            return err
+        }
+        remapTalentIDs(placed, ids)
+
+        for i := range placed {
+            query, args := InsertTalentQuery(&placed[i])
+            if _, err := w.Exec(query, args...); err != nil {
+                return idInUseError(err, "talent", placed[i].ID)
+            }
+            w.TrackTalent(placed[i].ID, &placed[i])
+        }
+        return nil
+    })
+    return newTabID, err
+}
+
+// showCloneTabDialog asks for the name, class mask and order index of the cloned tab
+func showCloneTabDialog(ctx *AppContext) {
+    if ctx.CurrentTab == nil {
+        dialog.ShowInformation(tr("Clone Tab"), tr("Select a TalentTab from the left first."), ctx.Window)
+        return
+    }
+    src := *ctx.CurrentTab
+
+    nameEntry := widget.NewEntry()
+    nameEntry.SetText(src.NameENUS + " Copy")
+    classMaskEntry := widget.NewEntry()
+    classMaskEntry.SetText(nullIntString(src.ClassMask))
+    orderEntry := widget.NewEntry()
+    orderEntry.SetText(nullIntString(src.OrderIndex))
+    keepSpells := widget.NewCheck(tr("Keep rank spells"), nil)
+    keepSpells.SetChecked(true)
+
+    items := []*widget.FormItem{
+        widget.NewFormItem(tr("Name"), nameEntry),
+        widget.NewFormItem(tr("Class mask"), classMaskEntry),
+        widget.NewFormItem(tr("Order index"), orderEntry),
+        widget.NewFormItem("", keepSpells),
+    }
+
+    dialog.ShowForm(trf("Clone Tab - %s", src.NameENUS), tr("Clone"), tr("Cancel"), items, func(ok bool) {
+        if !ok {
+            return
+        }
+
+        classMask, err := strconv.ParseInt(strings.TrimSpace(classMaskEntry.Text), 10, 64)
+        if err != nil {
+            dialog.ShowError(fmt.Errorf("invalid class mask %q", classMaskEntry.Text), ctx.Window)
+            return
+        }
+        orderIndex, err := strconv.ParseInt(strings.TrimSpace(orderEntry.Text), 10, 64)
+        if err != nil {
+            dialog.ShowError(fmt.Errorf("invalid order index %q", orderEntry.Text), ctx.Window)
+            return
+        }
+
+        newTabID, err := cloneTalentTab(ctx, src, CloneTabOptions{
+            Name:       strings.TrimSpace(nameEntry.Text),
+            ClassMask:  classMask,
+            OrderIndex: orderIndex,
+            KeepSpells: keepSpells.Checked,
+        })
+        if err != nil {
+            dialog.ShowError(err, ctx.Window)
+            return
+        }
+
+        reloadTabs(ctx)
+        msg := trf("Created tab %d.", newTabID)
+        if ctx.Patch != nil {
+            msg += " " + tr("The new tab is listed once the SQL patch has been applied.")
+        }
+        dialog.ShowInformation(tr("Clone Tab"), msg, ctx.Window)
+    }, ctx.Window)
+}
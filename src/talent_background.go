@@ -0,0 +1,153 @@
+// Copyright (c) 2025 TalentEditor
+//
+// TalentEditor is licensed under the MIT License.
+// See the LICENSE file for details.
+
+package main
+
+import (
+    "bytes"
+    "fmt"
+    "image"
+    "image/draw"
+    "image/png"
+    "os"
+    "path/filepath"
+    "strings"
+
+    "fyne.io/fyne/v2"
+    "fyne.io/fyne/v2/canvas"
+    "fyne.io/fyne/v2/container"
+)
+
+// The talent frame shows only part of the right and bottom background pieces,
+// the rest of these textures is padding to a power of two
+const (
+    backgroundRightVisible  = 0.6875    // 44 of 64 pixels
+    backgroundBottomVisible = 0.5859375 // 75 of 128 pixels
+)
+
+var backgroundPieces = []string{"TopLeft", "TopRight", "BottomLeft", "BottomRight"}
+
+// findTextureFile finds name.png or name.blp in dir, ignoring case. A PNG wins over a BLP.
+func findTextureFile(dir, name string) (string, bool) {
+    entries, err := os.ReadDir(dir)
+    if err != nil {
+        return "", false
+    }
+    var found string
+    for _, e := range entries {
+        ext := strings.ToLower(filepath.Ext(e.Name()))
+        if e.IsDir() || !strings.EqualFold(strings.TrimSuffix(e.Name(), filepath.Ext(e.Name())), name) {
+            continue
+        }
+        if ext == ".png" {
+            return filepath.Join(dir, e.Name()), true
+        }
+        if ext == ".blp" {
+            found = filepath.Join(dir, e.Name())
+        }
+    }
+    return found, found != ""
+}
+
+// loadTextureImage decodes a PNG or BLP texture
+func loadTextureImage(file string) (image.Image, error) {
+    data, err := os.ReadFile(file)
+    if err != nil {
+        return nil, err
+    }
+    if strings.EqualFold(filepath.Ext(file), ".blp") {
+        img, err := decodeBLP(data)
+        if err != nil {
+            return nil, fmt.Errorf("%s: %w", file, err)
+        }
+        return img, nil
+    }
+    img, err := png.Decode(bytes.NewReader(data))
+    if err != nil {
+        return nil, fmt.Errorf("%s: %w", file, err)
+    }
+    return img, nil
+}
+
+// composeTalentBackground joins the four pieces of a tab background the way the talent
+// frame places them: TopLeft and TopRight above BottomLeft and BottomRight, with only the
+// visible part of the right and bottom pieces
+func composeTalentBackground(pieces [4]image.Image) *image.NRGBA {
+    topLeft, topRight, bottomLeft, bottomRight := pieces[0], pieces[1], pieces[2], pieces[3]
+    leftWidth := topLeft.Bounds().Dx()
+    rightWidth := int(float64(topRight.Bounds().Dx()) * backgroundRightVisible)
+    topHeight := topLeft.Bounds().Dy()
+    bottomHeight := int(float64(bottomLeft.Bounds().Dy()) * backgroundBottomVisible)
+
+    img := image.NewNRGBA(image.Rect(0, 0, leftWidth+rightWidth, topHeight+bottomHeight))
+    place := func(piece image.Image, x, y, w, h int) {
+        draw.Draw(img, image.Rect(x, y, x+w, y+h), piece, piece.Bounds().Min, draw.Src)
+    }
+    place(topLeft, 0, 0, leftWidth, topHeight)
+    place(topRight, leftWidth, 0, rightWidth, topHeight)
+    place(bottomLeft, 0, topHeight, leftWidth, bottomHeight)
+    place(bottomRight, leftWidth, topHeight, rightWidth, bottomHeight)
+    return img
+}
+
+// loadTalentBackground loads and composes the background of a tab from the configured
+// folder, e.g. MageFire-TopLeft.blp. Returns nil when no folder is configured or a piece is missing.
+func loadTalentBackground(ctx *AppContext, background string) (fyne.Resource, error) {
+    folder := ctx.Config.Backgrounds.Folder
+    // The column may hold a client path, only the file name matters
+    if i := strings.LastIndexAny(background, `\/`); i >= 0 {
+        background = background[i+1:]
+    }
+    if folder == "" || background == "" {
+        return nil, nil
+    }
+
+    if res, ok := ctx.Backgrounds[strings.ToLower(background)]; ok {
+        return res, nil
+    }
+
+    // Missing and broken backgrounds are cached as nil, so the folder is not searched and
+    // a decode error is not reported again on every reload
+    res, err := composeBackgroundFiles(folder, background)
+    if ctx.Backgrounds == nil {
+        ctx.Backgrounds = make(map[string]fyne.Resource)
+    }
+    ctx.Backgrounds[strings.ToLower(background)] = res
+    return res, err
+}
+
+// composeBackgroundFiles loads the four pieces of a background and joins them, nil when a
+// piece is missing
+func composeBackgroundFiles(folder, background string) (fyne.Resource, error) {
+    var pieces [4]image.Image
+    for i, piece := range backgroundPieces {
+        file, ok := findTextureFile(folder, background+"-"+piece)
+        if !ok {
+            return nil, nil
+        }
+        img, err := loadTextureImage(file)
+        if err != nil {
+            return nil, err
+        }
+        pieces[i] = img
+    }
+    var buf bytes.Buffer
+    if err := png.Encode(&buf, composeTalentBackground(pieces)); err != nil {
+        return nil, err
+    }
+    return fyne.NewStaticResource(background+".png", buf.Bytes()), nil
+}
+
+// withTalentBackground places the background of the tab behind the grid, scaled to fit
+// its size without changing the aspect ratio of the art
+func withTalentBackground(ctx *AppContext, tab TalentTab, grid fyne.CanvasObject) (fyne.CanvasObject, error) {
+    res, err := loadTalentBackground(ctx, tab.Background.String)
+    if err != nil || res == nil {
+        return grid, err
+    }
+    bg := canvas.NewImageFromResource(res)
+    bg.FillMode = canvas.ImageFillContain
+    return container.NewStack(bg, container.NewPadded(grid)), nil
+}
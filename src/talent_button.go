@@ -6,6 +6,8 @@
 package main
 
 import (
+    "image/color"
+
     "fyne.io/fyne/v2"
     "fyne.io/fyne/v2/canvas"
     "fyne.io/fyne/v2/driver/desktop"
@@ -18,9 +20,16 @@ type TalentButton struct {
     widget.BaseWidget
     ttwidget.ToolTipWidgetExtend
 
-    Icon     fyne.Resource
-    OnTapped func()
-    BtnSize  fyne.Size
+    Icon              fyne.Resource
+    OnTapped          func()
+    OnTappedSecondary func(pos fyne.Position) // right click, receives the absolute position
+    OnShiftTapped     func()                  // click with shift held, falls back to OnTapped
+    OnDragged         func(e *fyne.DragEvent) // position is relative to the button
+    OnDragEnd         func()
+    BtnSize           fyne.Size
+    Highlight         color.Color // border color, nil for none
+
+    modifier fyne.KeyModifier // modifiers held on the last mouse down
 }
 
 // NewTalentButton constructor
@@ -41,39 +50,95 @@ func (b *TalentButton) ExtendBaseWidget(wid fyne.Widget) {
     b.ExtendToolTipWidget(wid)
 }
 
+// SetHighlight draws a colored border around the button, nil removes it
+func (b *TalentButton) SetHighlight(c color.Color) {
+    b.Highlight = c
+    b.Refresh()
+}
+
 // CreateRenderer draws the button
 func (b *TalentButton) CreateRenderer() fyne.WidgetRenderer {
     img := canvas.NewImageFromResource(b.Icon)
     img.FillMode = canvas.ImageFillContain
     img.SetMinSize(b.BtnSize)
 
-    return &talentButtonRenderer{
+    border := canvas.NewRectangle(color.Transparent)
+    border.StrokeWidth = 3
+
+    r := &talentButtonRenderer{
         button:  b,
         image:   img,
-        objects: []fyne.CanvasObject{img},
+        border:  border,
+        objects: []fyne.CanvasObject{img, border},
     }
+    r.Refresh()
+    return r
 }
 
 type talentButtonRenderer struct {
     button  *TalentButton
     image   *canvas.Image
+    border  *canvas.Rectangle
     objects []fyne.CanvasObject
 }
 
 // Base functions required for custom widgets
-func (r *talentButtonRenderer) Layout(size fyne.Size)        { r.image.Resize(r.button.BtnSize) }
+func (r *talentButtonRenderer) Layout(size fyne.Size) {
+    r.image.Resize(r.button.BtnSize)
+    r.border.Resize(r.button.BtnSize)
+}
 func (r *talentButtonRenderer) MinSize() fyne.Size           { return r.button.BtnSize }
-func (r *talentButtonRenderer) Refresh()                     { r.image.Resource = r.button.Icon; r.image.Refresh() }
 func (r *talentButtonRenderer) Objects() []fyne.CanvasObject { return r.objects }
 func (r *talentButtonRenderer) Destroy()                     {}
 
+func (r *talentButtonRenderer) Refresh() {
+    r.image.Resource = r.button.Icon
+    r.image.Refresh()
+
+    if r.button.Highlight != nil {
+        r.border.StrokeColor = r.button.Highlight
+    } else {
+        r.border.StrokeColor = color.Transparent
+    }
+    r.border.Refresh()
+}
+
 // Tapped triggers the button action
 func (b *TalentButton) Tapped(*fyne.PointEvent) {
+    if b.modifier&fyne.KeyModifierShift != 0 && b.OnShiftTapped != nil {
+        b.OnShiftTapped()
+        return
+    }
     if b.OnTapped != nil {
         b.OnTapped()
     }
 }
 
+// TappedSecondary triggers the context action
+func (b *TalentButton) TappedSecondary(e *fyne.PointEvent) {
+    if b.OnTappedSecondary != nil {
+        b.OnTappedSecondary(e.AbsolutePosition)
+    }
+}
+
+// MouseDown remembers the modifiers for the following tap
+func (b *TalentButton) MouseDown(e *desktop.MouseEvent) { b.modifier = e.Modifier }
+func (b *TalentButton) MouseUp(*desktop.MouseEvent)     {}
+
+// Dragged forwards drag events, used for box selection
+func (b *TalentButton) Dragged(e *fyne.DragEvent) {
+    if b.OnDragged != nil {
+        b.OnDragged(e)
+    }
+}
+
+// DragEnd finishes a drag
+func (b *TalentButton) DragEnd() {
+    if b.OnDragEnd != nil {
+        b.OnDragEnd()
+    }
+}
+
 // Hover events forwarded to tooltip
 func (b *TalentButton) MouseIn(e *desktop.MouseEvent)        { b.ToolTipWidgetExtend.MouseIn(e) }
 func (b *TalentButton) MouseMoved(e *desktop.MouseEvent)     { b.ToolTipWidgetExtend.MouseMoved(e) }
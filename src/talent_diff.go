@@ -0,0 +1,161 @@
+// Copyright (c) 2025 TalentEditor
+//
+// TalentEditor is licensed under the MIT License.
+// See the LICENSE file for details.
+
+package main
+
+import (
+    "database/sql"
+    "fmt"
+    "image/color"
+    "sort"
+)
+
+// ChangeKind classifies the difference of a talent between two data states
+type ChangeKind int
+
+const (
+    ChangeAdded ChangeKind = iota
+    ChangeRemoved
+    ChangeMoved   // only tier and/or column differ
+    ChangeChanged // any other field differs, position may differ as well
+)
+
+func (k ChangeKind) String() string {
+    switch k {
+    case ChangeAdded:
+        return "Added"
+    case ChangeRemoved:
+        return "Removed"
+    case ChangeMoved:
+        return "Moved"
+    default:
+        return "Changed"
+    }
+}
+
+// Color used to highlight talents with this kind of change
+func (k ChangeKind) Color() color.Color {
+    switch k {
+    case ChangeAdded:
+        return color.NRGBA{R: 0, G: 200, B: 0, A: 255}
+    case ChangeRemoved:
+        return color.NRGBA{R: 220, G: 0, B: 0, A: 255}
+    case ChangeMoved:
+        return color.NRGBA{R: 0, G: 140, B: 255, A: 255}
+    default:
+        return color.NRGBA{R: 255, G: 200, B: 0, A: 255}
+    }
+}
+
+// FieldDiff is a single differing talent field
+type FieldDiff struct {
+    Field string // English editor label
+    Label string // editor label in the UI language
+    Old   string
+    New   string
+}
+
+// TalentChange describes how one talent differs between an old and a new data state
+type TalentChange struct {
+    Kind   ChangeKind
+    ID     int
+    Old    *Talent // nil when added
+    New    *Talent // nil when removed
+    Fields []FieldDiff
+}
+
+// Moved reports whether the talent changed its grid position
+func (c TalentChange) Moved() bool {
+    return c.Old != nil && c.New != nil &&
+        (nullIntString(c.Old.TierID) != nullIntString(c.New.TierID) ||
+            nullIntString(c.Old.ColumnIndex) != nullIntString(c.New.ColumnIndex))
+}
+
+// diffTalents matches talents by ID and returns the changes ordered by talent ID
+func diffTalents(oldTalents, newTalents []Talent) []TalentChange {
+    oldMap := make(map[int]*Talent, len(oldTalents))
+    for i := range oldTalents {
+        oldMap[oldTalents[i].ID] = &oldTalents[i]
+    }
+    newMap := make(map[int]*Talent, len(newTalents))
+    for i := range newTalents {
+        newMap[newTalents[i].ID] = &newTalents[i]
+    }
+
+    var changes []TalentChange
+    for id, o := range oldMap {
+        n, ok := newMap[id]
+        if !ok {
+            changes = append(changes, TalentChange{Kind: ChangeRemoved, ID: id, Old: o})
+            continue
+        }
+
+        fields := talentFieldDiffs(o, n)
+        if len(fields) == 0 {
+            continue
+        }
+
+        change := TalentChange{Kind: ChangeChanged, ID: id, Old: o, New: n, Fields: fields}
+        onlyPosition := true
+        for _, f := range fields {
+            if f.Field != "Tier ID" && f.Field != "Column Index" {
+                onlyPosition = false
+            }
+        }
+        if onlyPosition {
+            change.Kind = ChangeMoved
+        }
+        changes = append(changes, change)
+    }
+    for id, n := range newMap {
+        if _, ok := oldMap[id]; !ok {
+            changes = append(changes, TalentChange{Kind: ChangeAdded, ID: id, New: n})
+        }
+    }
+
+    sort.Slice(changes, func(i, j int) bool { return changes[i].ID < changes[j].ID })
+    return changes
+}
+
+// talentFieldDiffs lists the fields that differ between two versions of a talent,
+// using the same labels as the talent editor. NULL and 0 are treated as equal.
+func talentFieldDiffs(a, b *Talent) []FieldDiff {
+    var diffs []FieldDiff
+    addLabeled := func(field, label string, x, y sql.NullInt64) {
+        xs, ys := nullIntString(x), nullIntString(y)
+        if xs != ys {
+            diffs = append(diffs, FieldDiff{Field: field, Label: label, Old: xs, New: ys})
+        }
+    }
+    add := func(field string, x, y sql.NullInt64) { addLabeled(field, tr(field), x, y) }
+    addNumbered := func(format string, n int, x, y sql.NullInt64) {
+        addLabeled(fmt.Sprintf(format, n), trf(format, n), x, y)
+    }
+
+    add("Spec ID", a.SpecID, b.SpecID)
+    add("Tier ID", a.TierID, b.TierID)
+    add("Column Index", a.ColumnIndex, b.ColumnIndex)
+    for i := 0; i < 9; i++ {
+        addNumbered("Rank %d", i+1, a.Rank[i], b.Rank[i])
+    }
+    for i := 0; i < 3; i++ {
+        addNumbered("Pre-requisite Talent ID %d", i+1, a.PreReqTalent[i], b.PreReqTalent[i])
+        addNumbered("Pre-requisite Rank %d", i+1, a.PreReqRank[i], b.PreReqRank[i])
+    }
+    add("Flags", a.Flags, b.Flags)
+    add("Required Spell ID", a.ReqSpellID, b.ReqSpellID)
+    add("Allow for Pet Flags 1", a.AllowForPetFlags1, b.AllowForPetFlags1)
+    add("Allow for Pet Flags 2", a.AllowForPetFlags2, b.AllowForPetFlags2)
+
+    return diffs
+}
+
+// nullIntString formats a nullable DBC integer, NULL is shown as 0 like in the editor
+func nullIntString(n sql.NullInt64) string {
+    if n.Valid {
+        return fmt.Sprintf("%d", n.Int64)
+    }
+    return "0"
+}
@@ -9,6 +9,7 @@ import (
     "archive/zip"
     "bytes"
     "database/sql"
+    "flag"
     "fmt"
     "image"
     "image/color"
@@ -44,6 +45,8 @@ const (
     NUM_TALENT_COLUMNS   = 4
 )
 
+const windowTitle = "WoW 3.3.5 Talent Editor - MySQL"
+
 type TalentTab struct {
     ID             int
     NameENUS       string
@@ -75,22 +78,65 @@ type ChrClass struct {
     PetName  string
 }
 
+type CreatureFamily struct {
+    ID             int
+    NameENUS       string
+    CategoryEnumID int // bit in the talent pet flags
+    PetTalentType  int // bit in the pet tab mask, -1 for families without pet talents
+}
+
 type Spell struct {
-    ID       int
-    NameENUS string
-    IconID   sql.NullInt64
-    Desc     string
+    ID        int
+    NameENUS  string
+    IconID    sql.NullInt64
+    Desc      string
+    LocalName string // name in the display locale, empty when not translated
+    LocalDesc string // description in the display locale, empty when not translated
+}
+
+// DisplayName returns the name in the display locale, falling back to enUS
+func (s Spell) DisplayName() string {
+    if s.LocalName != "" {
+        return s.LocalName
+    }
+    return s.NameENUS
+}
+
+// DisplayDesc returns the description in the display locale, falling back to enUS
+func (s Spell) DisplayDesc() string {
+    if s.LocalDesc != "" {
+        return s.LocalDesc
+    }
+    return s.Desc
 }
 
 type AppContext struct {
     DB              *sql.DB
+    CompareDB       *sql.DB // opened on first use from Config.Compare
+    Config          *Config
     GridContainer   *fyne.Container
     EditorContainer *fyne.Container
     Window          fyne.Window
+    TabsTree        *widget.Tree
+    TabFilter       *widget.Entry // filters the tab tree by class, tab or talent name
+    CurrentTab      *TalentTab
+    Patch           *SQLPatch // pending statements, non-nil in dry-run mode
+    ReadOnly        bool      // all writes are refused
+    Locale          string    // client locale of displayed tab names and spell texts, e.g. deDE
+    Clipboard       *TalentClipboard
+    SwapSource      *SwapSource
+    Tooltip         TooltipOptions
+    Selection       *TalentSelection
+    GridButtons     map[int]*TalentButton // buttons of the current grid by talent ID
     
     // Caches
-    SpellIcons map[int]string
-    Spells     map[int]Spell
+    SpellIcons       map[int]string
+    Spells           map[int]Spell
+    SpellEffects     map[int]SpellEffects
+    SpellDurations   map[int]SpellDuration
+    SpellRadii       map[int]SpellRadius
+    CreatureFamilies map[int]CreatureFamily
+    Backgrounds      map[string]fyne.Resource // composed tab backgrounds by lower case name, nil if missing
 }
 
 func init() {
@@ -111,8 +157,12 @@ func init() {
 }
 
 func main() {
+    dryRun := flag.Bool("dry-run", false, "collect writes into a SQL patch instead of executing them")
+    readOnly := flag.Bool("read-only", false, "browse the database without allowing any changes")
+    flag.Parse()
+
     a := app.New()
-    window := a.NewWindow("WoW 3.3.5 Talent Editor - MySQL")
+    window := a.NewWindow(windowTitle)
     window.Resize(fyne.NewSize(1000, 1080))
 
     // Load config
@@ -123,10 +173,17 @@ func main() {
         return
     }
     if created {
-        dialog.ShowInformation("Info", fmt.Sprintf("Template config.json created at %s. Please edit it and restart.", cfgPath), window)
+        // The template configures no UI language, the OS language is used
+        setUILanguage("")
+        dialog.ShowInformation(tr("Info"), trf("Template config.json created at %s. Please edit it and restart.", cfgPath), window)
         return
     }
 
+    // UI strings are looked up in the configured language from here on
+    if err := setUILanguage(cfg.Locale.UI); err != nil {
+        dialog.ShowError(err, window)
+    }
+
     // Open DB connection
     db, err := openDB(cfg.DBC)
     if err != nil {
@@ -135,26 +192,35 @@ func main() {
     }
     defer db.Close()
 
+    // Icons in the search paths replace the embedded ones
+    if externalIcons, err = indexIconSearchPaths(cfg.Icons.SearchPaths); err != nil {
+        dialog.ShowError(err, window)
+    }
+
     // Add theme selector config
     a.Settings().SetTheme(&customTheme{base: theme.DefaultTheme(), variant: theme.VariantDark})
 
-    // Left: talent tabs list
-    tabsList := widget.NewList(
-        func() int { return 0 },
-        func() fyne.CanvasObject { return widget.NewLabel("placeholder") },
-        func(i widget.ListItemID, o fyne.CanvasObject) {},
+    // Left: talent tab tree with a filter
+    tabsTree := widget.NewTree(
+        func(widget.TreeNodeID) []widget.TreeNodeID { return nil },
+        func(widget.TreeNodeID) bool { return false },
+        func(bool) fyne.CanvasObject { return widget.NewLabel("placeholder") },
+        func(widget.TreeNodeID, bool, fyne.CanvasObject) {},
     )
+    tabFilter := widget.NewEntry()
+    tabFilter.SetPlaceHolder(tr("Filter classes, tabs, talents"))
+    tabTools := container.NewVBox(tabFilter)
 
     // Center: Talent grid
-    gridContainer := container.NewVBox(widget.NewLabel("Select a TalentTab from the left"))
+    gridContainer := container.NewVBox(widget.NewLabel(tr("Select a TalentTab from the left")))
     
     // Right: Talent editor
-    editorContainer := container.NewVBox(widget.NewLabel("Select a talent cell to edit"))
+    editorContainer := container.NewVBox(widget.NewLabel(tr("Select a talent cell to edit")))
     
     // Format column labels with spacing to enforce min col width
     formatLabel:= func(text string, padding int) *widget.Label {
         spaces := strings.Repeat(" ", padding)
-        lbl := widget.NewLabel(spaces + text + spaces)
+        lbl := widget.NewLabel(spaces + tr(text) + spaces)
         
         lbl.TextStyle = fyne.TextStyle{
             Bold:   true,
@@ -173,7 +239,7 @@ func main() {
     mainContainer := container.NewBorder(
         nil,
         nil,
-        container.NewMax(container.NewBorder(container.NewCenter(talentTabLabel), nil, nil, nil, tabsList)),
+        container.NewMax(container.NewBorder(container.NewVBox(container.NewCenter(talentTabLabel), tabTools), nil, nil, nil, tabsTree)),
         container.NewMax(container.NewBorder(container.NewCenter(editorLabel), nil, nil, nil, editorContainer)),
         container.NewMax(container.NewBorder(container.NewCenter(gridLabel), nil, nil, nil, gridContainer)),
     )
@@ -182,110 +248,101 @@ func main() {
     // Create application context map
     ctx := &AppContext{
         DB:              db,
+        Config:          cfg,
+        ReadOnly:        *readOnly || cfg.DBC.ReadOnly,
         GridContainer:   gridContainer,
         EditorContainer: editorContainer,
         Window:          window,
+        TabsTree:        tabsTree,
+        TabFilter:       tabFilter,
+    }
+    if *dryRun {
+        ctx.Patch = NewSQLPatch()
+    }
+
+    // Tab names and spell texts are shown in the configured client locale
+    ctx.Locale = defaultLocale
+    if l := cfg.Locale.Display; l != "" {
+        if isClientLocale(l) {
+            ctx.Locale = l
+        } else {
+            dialog.ShowError(fmt.Errorf("unknown display locale %q, use one of %s", l, strings.Join(clientLocales, ", ")), window)
+        }
+    }
+    tabTools.Objects = []fyne.CanvasObject{
+        container.NewBorder(nil, nil, nil, newLocaleSelect(ctx), tabFilter),
     }
+    updateWindowTitle(ctx)
     
-    loadTabs(ctx, tabsList)
+    window.SetMainMenu(buildMainMenu(ctx))
+
+    reloadTabs(ctx)
     window.ShowAndRun()
+
+    if ctx.CompareDB != nil {
+        ctx.CompareDB.Close()
+    }
 }
 
-// loadTabs constructs the class and pet talent tabs on the left hand pane
-func loadTabs(ctx *AppContext, tabsList *widget.List) {
+// reloadTabs rebuilds the talent tab list, e.g. after a tab was created
+func reloadTabs(ctx *AppContext) {
+    loadTabs(ctx, ctx.TabsTree)
+}
+
+// loadTabs constructs the class and pet talent tab tree on the left hand pane
+func loadTabs(ctx *AppContext, tabsTree *widget.Tree) {
     tabs, err := GetAllTalentTabs(ctx)
     if err != nil {
         dialog.ShowError(err, ctx.Window)
         return
     }
 
-    // Load classes
-    classMap, err := GetAllClasses(ctx)
+    data, err := loadTabBrowserData(ctx)
     if err != nil {
         dialog.ShowError(err, ctx.Window)
         return
     }
 
-    // Group tabs by class
-    classTabs := make(map[string][]TalentTab)
-    var petTabs []TalentTab
-    for _, t := range tabs {
-        if t.CreatureFamily.Valid && t.CreatureFamily.Int64 > 0 {
-            petTabs = append(petTabs, t)
-            continue
-        }
-        assigned := false
-        if t.ClassMask.Valid {
-            mask := t.ClassMask.Int64
-            for _, class := range classMap {
-                if mask&(1<<(class.ID-1)) != 0 {
-                    classTabs[class.NameENUS] = append(classTabs[class.NameENUS], t)
-                    assigned = true
-                }
-            }
-        }
-        if !assigned {
-            classTabs["Unknown"] = append(classTabs["Unknown"], t)
-        }
-    }
+    full := buildTabTree(tabs, data)
+    shown := full.filter(ctx.TabFilter.Text)
 
-    // Flatten all tabs for display
-    var displayTabs []struct {
-        Group string
-        Tab   TalentTab
-    }
-
-    // Add all non-pet tabs first
-    for className, tabs := range classTabs {
-        for _, t := range tabs {
-            displayTabs = append(displayTabs, struct {
-                Group string
-                Tab   TalentTab
-            }{Group: className, Tab: t})
+    // Update the talent tab tree
+    tabsTree.ChildUIDs = func(uid widget.TreeNodeID) []widget.TreeNodeID { return shown.Children[uid] }
+    tabsTree.IsBranch = func(uid widget.TreeNodeID) bool { return uid == "" || full.Nodes[uid].Tab == nil }
+    tabsTree.CreateNode = func(bool) fyne.CanvasObject { return newTabTreeNode() }
+    tabsTree.UpdateNode = func(uid widget.TreeNodeID, _ bool, o fyne.CanvasObject) {
+        if node, ok := full.Nodes[uid]; ok {
+            updateTabTreeNode(node, o)
         }
     }
-
-    // Append pet tabs at the bottom
-    for _, t := range petTabs {
-        displayTabs = append(displayTabs, struct {
-            Group string
-            Tab   TalentTab
-        }{Group: "Pet", Tab: t})
-    }
-
-    // Sort entire displayTabs slice by Tab.ID
-    sort.Slice(displayTabs, func(i, j int) bool {
-        // Pets go after non-pets
-        if displayTabs[i].Group == "Pet" && displayTabs[j].Group != "Pet" {
-            return false
+    tabsTree.OnSelected = func(uid widget.TreeNodeID) {
+        node, ok := full.Nodes[uid]
+        if !ok {
+            return
         }
-        if displayTabs[i].Group != "Pet" && displayTabs[j].Group == "Pet" {
-            return true
+        if node.Tab == nil {
+            tabsTree.ToggleBranch(uid)
+            tabsTree.UnselectAll()
+            return
         }
-        return displayTabs[i].Tab.ID < displayTabs[j].Tab.ID
-    })
-
-    // Update the talent tab list
-    tabsList.Length = func() int { return len(displayTabs) }
-    tabsList.CreateItem = func() fyne.CanvasObject { return widget.NewLabel("") }
-    tabsList.UpdateItem = func(i widget.ListItemID, o fyne.CanvasObject) {
-        item := displayTabs[i]
-        o.(*widget.Label).SetText(fmt.Sprintf("[%s] %s", item.Group, item.Tab.NameENUS))
+        loadTalentsForTab(ctx, *node.Tab)
     }
-    tabsList.OnSelected = func(id widget.ListItemID) {
-        if id < 0 || id >= len(displayTabs) {
-            return
+
+    ctx.TabFilter.OnChanged = func(query string) {
+        shown = full.filter(query)
+        tabsTree.Refresh()
+        if query != "" {
+            tabsTree.OpenAllBranches()
         }
-        tab := displayTabs[id].Tab
-        loadTalentsForTab(ctx, tab)
     }
-    tabsList.Refresh()
+    tabsTree.Refresh()
 }
 
 // loadTalentsForTab queries talents and builds the visual talent grid
 func loadTalentsForTab(ctx *AppContext, tab TalentTab) {
     // Clear previous content
     ctx.GridContainer.Objects = nil
+    ctx.CurrentTab = &tab
     resetEditorContainer(ctx)
 
     // Load talents from DB
@@ -295,16 +352,21 @@ func loadTalentsForTab(ctx *AppContext, tab TalentTab) {
         return
     }
 
-    // Load all relevant Spells from the talents
+    // Show pending dry-run changes on top of the database state
+    if ctx.Patch != nil {
+        talents = ctx.Patch.OverlayTalents(talents, func(t *Talent) bool {
+            return t.SpecID.Valid && int(t.SpecID.Int64) == tab.ID
+        })
+        talentSpellIds = allRankSpellIDs(talents)
+    }
+
+    // Load the spells of every rank, the tooltips describe all of them
     spells, err := GetSpellsByIDs(ctx, talentSpellIds)
     if err != nil {
         dialog.ShowError(err, ctx.Window)
         return
     }
 
-    // Map talents into grid
-    grid := mapTalentsToGrid(talents, MAX_NUM_TALENT_TIERS, NUM_TALENT_COLUMNS)
-
     // Load Spell Icons
     iconIDs, err := GetAllSpellIcons(ctx)
     if err != nil {
@@ -312,7 +374,48 @@ func loadTalentsForTab(ctx *AppContext, tab TalentTab) {
         return
     }
 
-    // Build talent buttons using custom grid layout
+    // In-game style tooltips
+    describe := spellDescriber(ctx, spells)
+    talentsByID := make(map[int]*Talent, len(talents))
+    for i := range talents {
+        talentsByID[talents[i].ID] = &talents[i]
+    }
+    tooltipFor := func(t *Talent) string {
+        return talentTooltip(t, tabName(tab, ctx.Locale), talentsByID, spells, describe, ctx.Tooltip)
+    }
+
+    reload := func() { loadTalentsForTab(ctx, tab) }
+    var cells []*TalentButton
+    gridWrapper, buttons := buildTalentGrid(talents, func(t *Talent, r, c int, buttonSize fyne.Size) *TalentButton {
+        btn := createTalentButton(ctx, tab, t, r, c, iconIDs, buttonSize, spells, tooltipFor, reload)
+        cells = append(cells, btn)
+        return btn
+    })
+    ctx.GridButtons = buttons
+    attachBoxSelect(ctx, tab, gridWrapper, cells, talents, reload)
+
+    // The tab's background art is drawn behind the grid when the textures are available
+    gridView, err := withTalentBackground(ctx, tab, gridWrapper)
+    if err != nil {
+        dialog.ShowError(err, ctx.Window)
+    }
+
+    ctx.GridContainer.Add(newTooltipControls(ctx, reload))
+    ctx.GridContainer.Add(container.NewCenter(gridView))
+    ctx.GridContainer.Refresh()
+
+    // Keep the selection across reloads of the same tab
+    syncSelection(ctx, tab, talents)
+    if ctx.Selection != nil {
+        refreshSelection(ctx, tab, reload)
+    }
+}
+
+// buildTalentGrid lays out one button per grid cell using the custom grid layout and
+// draws the prerequisite arrows. Buttons of placed talents are returned by talent ID.
+func buildTalentGrid(talents []Talent, makeButton func(t *Talent, row, col int, buttonSize fyne.Size) *TalentButton) (*fyne.Container, map[int]*TalentButton) {
+    grid := mapTalentsToGrid(talents, MAX_NUM_TALENT_TIERS, NUM_TALENT_COLUMNS)
+
     iconSize := 46
     buttonSize := fyne.NewSize(float32(iconSize), float32(iconSize))
     buttonMap := make(map[int]*TalentButton)
@@ -329,8 +432,7 @@ func loadTalentsForTab(ctx *AppContext, tab TalentTab) {
     for r := 0; r < MAX_NUM_TALENT_TIERS; r++ {
         for c := 0; c < NUM_TALENT_COLUMNS; c++ {
             t := grid[r][c]
-            tb := createTalentButton(ctx, tab, t, r, c, iconIDs, buttonSize, spells,
-                func() { loadTalentsForTab(ctx, tab) })
+            tb := makeButton(t, r, c, buttonSize)
 
             gridWrapper.Add(tb)
             
@@ -344,8 +446,37 @@ func loadTalentsForTab(ctx *AppContext, tab TalentTab) {
     // Draw arrows between talents
     drawTalentArrows(gridWrapper, buttonMap, talentMap)
 
-    ctx.GridContainer.Add(container.NewCenter(gridWrapper))
-    ctx.GridContainer.Refresh()
+    return gridWrapper, buttonMap
+}
+
+// isPetTab reports whether the tab is a pet talent tab
+func isPetTab(t TalentTab) bool {
+    return t.CreatureFamily.Valid && t.CreatureFamily.Int64 > 0
+}
+
+// tabClassNames decodes the tab's ClassMask against ChrClasses, "Unknown" when nothing matches
+func tabClassNames(t TalentTab, classMap map[int]ChrClass) []string {
+    var names []string
+    if t.ClassMask.Valid {
+        mask := t.ClassMask.Int64
+        for _, class := range classMap {
+            if mask&(1<<(class.ID-1)) != 0 {
+                names = append(names, class.NameENUS)
+            }
+        }
+    }
+    if len(names) == 0 {
+        names = append(names, "Unknown")
+    }
+    sort.Strings(names)
+    return names
+}
+
+// reloadCurrentTab rebuilds the talent grid of the selected tab, if any
+func reloadCurrentTab(ctx *AppContext) {
+    if ctx.CurrentTab != nil {
+        loadTalentsForTab(ctx, *ctx.CurrentTab)
+    }
 }
 
 func mapTalentsToGrid(talents []Talent, rows, cols int) [][]*Talent {
@@ -380,6 +511,9 @@ func openTalentEditor(ctx *AppContext, t *Talent, isNew bool, reloadTab func())
     makeEntry := func(def string) *widget.Entry {
         e := widget.NewEntry()
         e.SetText(def)
+        if ctx.ReadOnly {
+            e.Disable()
+        }
         return e
     }
 
@@ -400,9 +534,10 @@ func openTalentEditor(ctx *AppContext, t *Talent, isNew bool, reloadTab func())
     // Map for saving values
     fields := map[string]fyne.CanvasObject{}
 
-    // Helper to create form items
-    makeFormItem := func(label string, w fyne.CanvasObject) *widget.FormItem {
-        fields[label] = w
+    // Helpers to create form items. Fields are keyed by the English label, the
+    // shown label is translated.
+    formItem := func(key, label string, w fyne.CanvasObject) *widget.FormItem {
+        fields[key] = w
         lbl := widget.NewLabel(label)
         lbl.Alignment = fyne.TextAlignLeading
         hbox := container.New(layout.NewGridLayout(2), lbl, w)
@@ -411,6 +546,12 @@ func openTalentEditor(ctx *AppContext, t *Talent, isNew bool, reloadTab func())
             Widget: hbox,
         }
     }
+    makeFormItem := func(label string, w fyne.CanvasObject) *widget.FormItem {
+        return formItem(label, tr(label), w)
+    }
+    makeNumberedItem := func(format string, n int, w fyne.CanvasObject) *widget.FormItem {
+        return formItem(fmt.Sprintf(format, n), trf(format, n), w)
+    }
 
     // Build form entries
     talentID := useLabel(fmt.Sprintf("%d", t.ID))
@@ -443,40 +584,75 @@ func openTalentEditor(ctx *AppContext, t *Talent, isNew bool, reloadTab func())
         makeFormItem("Column Index", colEntry),
     }
 
+    // Rank spells can be edited in the spell editor. Reloading the grid resets the editor,
+    // so the open form is put back with its unsaved edits.
+    spellSaved := func() {
+        editor := ctx.EditorContainer.Objects
+        reloadTab()
+        ctx.EditorContainer.Objects = editor
+        ctx.EditorContainer.Refresh()
+    }
     for i := 0; i < 9; i++ {
-        formItems = append(formItems, makeFormItem(fmt.Sprintf("Rank %d", i+1), rankEntries[i]))
+        label := fmt.Sprintf("Rank %d", i+1)
+        entry := rankEntries[i]
+        fields[label] = entry
+        spellBtn := widget.NewButton(tr("Spell..."), func() {
+            id, _ := strconv.Atoi(strings.TrimSpace(entry.Text))
+            showSpellEditor(ctx, id, spellSaved)
+        })
+        row := container.New(layout.NewGridLayout(2), widget.NewLabel(trf("Rank %d", i+1)), container.NewBorder(nil, nil, nil, spellBtn, entry))
+        formItems = append(formItems, &widget.FormItem{Widget: row})
     }
     for i := 0; i < 3; i++ {
-        formItems = append(formItems, makeFormItem(fmt.Sprintf("Pre-requisite Talent ID %d", i+1), preTalentEntries[i]))
-        formItems = append(formItems, makeFormItem(fmt.Sprintf("Pre-requisite Rank %d", i+1), preRankEntries[i]))
+        formItems = append(formItems, makeNumberedItem("Pre-requisite Talent ID %d", i+1, preTalentEntries[i]))
+        formItems = append(formItems, makeNumberedItem("Pre-requisite Rank %d", i+1, preRankEntries[i]))
+    }
+    // Bitfields are shown decoded next to the raw value
+    bitfieldItem := func(title string, entry *widget.Entry, name BitNamer) *widget.FormItem {
+        return &widget.FormItem{Widget: newBitfieldView(ctx, title, entry, name)}
     }
     formItems = append(formItems,
         makeFormItem("Flags", flagsEntry),
+        bitfieldItem(tr("Flags"), flagsEntry, talentFlagBitName),
         makeFormItem("Required Spell ID", reqSpellEntry),
         makeFormItem("Allow for Pet Flags 1", allowPet1Entry),
+        bitfieldItem(tr("Allow for Pet Flags 1"), allowPet1Entry, petFlagBitNamer(ctx, 0)),
         makeFormItem("Allow for Pet Flags 2", allowPet2Entry),
+        bitfieldItem(tr("Allow for Pet Flags 2"), allowPet2Entry, petFlagBitNamer(ctx, 32)),
     )
 
     form := widget.NewForm(formItems...)
     formFiller := container.NewMax(form)
 
     // Build editor buttons
-    saveBtn := widget.NewButton("Save", func() {
+    saveBtn := widget.NewButton(tr("Save"), func() {
         saveTalentHandler(ctx, t, isNew, fields, reloadTab)
     })
-    cancelBtn := widget.NewButton("Cancel", func() {
+    cancelBtn := widget.NewButton(tr("Cancel"), func() {
         resetEditorContainer(ctx)
     })
-    deleteBtn := widget.NewButton("Delete", func() {
+    deleteBtn := widget.NewButton(tr("Delete"), func() {
         deleteTalentHandler(ctx, t, reloadTab)
     })
     deleteBtn.Importance = widget.DangerImportance
+    // Rank spells of saved talents can be generated from a template spell
+    wizardBtn := widget.NewButton(tr("Rank Wizard..."), func() {
+        showRankWizard(ctx, t, func(updated *Talent) {
+            reloadTab()
+            openTalentEditor(ctx, updated, false, reloadTab)
+        })
+    })
+    if ctx.ReadOnly {
+        saveBtn.Disable()
+        deleteBtn.Disable()
+        wizardBtn.Disable()
+    }
 
     var btnRow fyne.CanvasObject
     if isNew {
         btnRow = NewEditorButtonRow(container.NewHBox(saveBtn, cancelBtn), nil)
     } else {
-        btnRow = NewEditorButtonRow(container.NewHBox(saveBtn, cancelBtn), deleteBtn)
+        btnRow = NewEditorButtonRow(container.NewHBox(saveBtn, cancelBtn, wizardBtn), deleteBtn)
     }
 
     ctx.EditorContainer.Objects = []fyne.CanvasObject{
@@ -708,46 +884,106 @@ func createTalentButton(
     iconIDs map[int]string,
     buttonSize fyne.Size,
     spells map[int]Spell,
+    tooltipFor func(t *Talent) string,
     reloadTab func(),
 ) *TalentButton {
-    iconResource := theme.BrokenImageIcon()
-    tooltip := ""
+    iconResource, tooltip := talentIconAndTooltip(talent, iconIDs, buttonSize, spells)
+    if talent != nil && tooltipFor != nil {
+        tooltip = tooltipFor(talent)
+    }
     var onTap func()
 
     if talent == nil {
-        iconResource = NewTransparentIconWithBorder(
+        if ctx.ReadOnly {
+            tooltip += " (" + tr("read-only") + ")"
+        } else {
+            onTap = func() {
+                emptyTalent := NewEmptyTalent(tab.ID, row, column)
+                openTalentEditor(ctx, emptyTalent, true, reloadTab)
+            }
+        }
+    } else {
+        tRef := talent
+        onTap = func() {
+            openTalentEditor(ctx, tRef, false, reloadTab)
+        }
+    }
+
+    // A plain click ends a multi-selection
+    if onTap != nil {
+        open := onTap
+        onTap = func() {
+            clearSelection(ctx)
+            open()
+        }
+    }
+
+    btn := NewTalentButton(iconResource, buttonSize, tooltip, onTap)
+    if talent != nil {
+        btn.OnShiftTapped = func() { toggleSelection(ctx, tab, *talent, reloadTab) }
+    }
+    btn.OnTappedSecondary = func(pos fyne.Position) {
+        showCellMenu(ctx, tab, talent, row, column, pos, reloadTab)
+    }
+    return btn
+}
+
+// talentIconAndTooltip resolves the icon and tooltip of a grid cell from the talent's first rank spell
+func talentIconAndTooltip(talent *Talent, iconIDs map[int]string, buttonSize fyne.Size, spells map[int]Spell) (fyne.Resource, string) {
+    if talent == nil {
+        iconResource := NewTransparentIconWithBorder(
             int(buttonSize.Width),
             int(buttonSize.Height),
             color.NRGBA{R: 255, G: 255, B: 255, A: 255},
             4,
         )
-        tooltip = "Empty talent slot"
-        onTap = func() {
-            emptyTalent := NewEmptyTalent(tab.ID, row, column)
-            openTalentEditor(ctx, emptyTalent, true, reloadTab)
-        }
-    } else {
-        if talent.Rank[0].Valid {
-            rankSpellID := int(talent.Rank[0].Int64)
-            if spell, ok := spells[rankSpellID]; ok && spell.IconID.Valid {
-                if iconFile, ok := iconIDs[int(spell.IconID.Int64)]; ok {
-                    name := strings.ToLower(iconFile + ".png")
-                    if actual, ok := iconLookup[name]; ok {
-                        if data, err := fs.ReadFile(iconsFS, actual); err == nil {
-                            iconResource = fyne.NewStaticResource(actual, data)
-                        }
-                    }
-                }
-                tooltip = fmt.Sprintf("%s\nID: %d\n%s", spell.NameENUS, spell.ID, spell.Desc)
+        return iconResource, tr("Empty talent slot")
+    }
+
+    iconResource := theme.BrokenImageIcon()
+    tooltip := ""
+    if talent.Rank[0].Valid {
+        rankSpellID := int(talent.Rank[0].Int64)
+        if spell, ok := spells[rankSpellID]; ok && spell.IconID.Valid {
+            if res := spellIconResource(iconIDs, int(spell.IconID.Int64)); res != nil {
+                iconResource = res
             }
+            tooltip = fmt.Sprintf("%s\nID: %d\n%s", spell.DisplayName(), spell.ID, spell.DisplayDesc())
         }
-        tRef := talent
-        onTap = func() {
-            openTalentEditor(ctx, tRef, false, reloadTab)
-        }
     }
+    return iconResource, tooltip
+}
+
+// spellIconResource returns the icon of a SpellIcon ID, nil if there is none
+func spellIconResource(iconIDs map[int]string, iconID int) fyne.Resource {
+    iconFile, ok := iconIDs[iconID]
+    if !ok {
+        return nil
+    }
+    return iconResourceByName(iconFile)
+}
 
-    return NewTalentButton(iconResource, buttonSize, tooltip, onTap)
+// iconResourceByName returns an icon by its file name without extension, nil if there is none.
+// Icons are loaded once and shared by all views.
+func iconResourceByName(iconFile string) fyne.Resource {
+    return cachedIconResource(iconFile, loadIconResource)
+}
+
+// loadIconResource loads an icon from the icon search paths or the embedded icons
+func loadIconResource(iconFile string) fyne.Resource {
+    if res := externalIconResource(iconFile); res != nil {
+        return res
+    }
+    name := strings.ToLower(iconFile + ".png")
+    actual, ok := iconLookup[name]
+    if !ok {
+        return nil
+    }
+    data, err := fs.ReadFile(iconsFS, actual)
+    if err != nil {
+        return nil
+    }
+    return fyne.NewStaticResource(actual, data)
 }
 
 func saveTalentHandler(ctx *AppContext, talent *Talent, isNew bool, formFields map[string]fyne.CanvasObject, reloadTab func()) {
@@ -813,7 +1049,18 @@ func saveTalentHandler(ctx *AppContext, talent *Talent, isNew bool, formFields m
 }
 
 func deleteTalentHandler(ctx *AppContext, talent *Talent, reloadTab func()) {
-    confirm := dialog.NewConfirm("Confirm Delete", "Are you sure you want to delete this talent?", func(yes bool) {
+    // Talents requiring this one need their prerequisites cleared or re-pointed first
+    all, err := loadAllTalents(ctx)
+    if err != nil {
+        dialog.ShowError(err, ctx.Window)
+        return
+    }
+    if dependents := findDependents(all, talent.ID); len(dependents) > 0 {
+        showDeleteWithDependents(ctx, talent, all, dependents, reloadTab)
+        return
+    }
+
+    confirm := dialog.NewConfirm(tr("Confirm Delete"), tr("Are you sure you want to delete this talent?"), func(yes bool) {
         if !yes {
             return
         }
@@ -828,22 +1075,36 @@ func deleteTalentHandler(ctx *AppContext, talent *Talent, reloadTab func()) {
 }
 
 func updateTalent(ctx *AppContext, talent *Talent) error {
-    query, args := UpdateTalentQuery(talent)
-    _, err := execWithDebug(ctx.DB, query, args...)
-    return err
+    return runWrite(ctx, func(w *WriteTx) error {
+        query, args := UpdateTalentQuery(talent)
+        if _, err := w.Exec(query, args...); err != nil {
+            return err
+        }
+        w.TrackTalent(talent.ID, talent)
+        return nil
+    })
 }
 
 func insertTalent(ctx *AppContext, talent *Talent) error {
-    if talent.ID == 0 {
-        var maxID int64
-        err := ctx.DB.QueryRow("SELECT COALESCE(MAX(id), 0) FROM Talent").Scan(&maxID)
-        if err != nil {
-            return err
+    allocated := talent.ID == 0
+    err := runWrite(ctx, func(w *WriteTx) error {
+        if talent.ID == 0 {
+            ids, err := allocateTalentIDs(ctx, w, 1)
+            if err != nil {
+                return err
+            }
+            talent.ID = ids[0]
+        }
+        query, args := InsertTalentQuery(talent)
+        if _, err := w.Exec(query, args...); err != nil {
+            return idInUseError(err, "talent", talent.ID)
         }
-        talent.ID = int(maxID + 1)
+        w.TrackTalent(talent.ID, talent)
+        return nil
+    })
+    if err != nil && allocated {
+        talent.ID = 0
     }
-    query, args := InsertTalentQuery(talent)
-    _, err := execWithDebug(ctx.DB, query, args...)
     return err
 }
 
@@ -851,13 +1112,42 @@ func deleteTalent(ctx *AppContext, talent *Talent) error {
     if talent == nil || talent.ID == 0 {
         return fmt.Errorf("invalid talent")
     }
-    query, args := DeleteTalentQuery(talent.ID)
-    _, err := execWithDebug(ctx.DB, query, args...)
-    return err
+    return runWrite(ctx, func(w *WriteTx) error {
+        query, args := DeleteTalentQuery(talent.ID)
+        if _, err := w.Exec(query, args...); err != nil {
+            return err
+        }
+        w.TrackTalent(talent.ID, nil)
+        return nil
+    })
+}
+
+// loadAllTalents reads the talents of all tabs, with pending dry-run changes applied
+func loadAllTalents(ctx *AppContext) ([]Talent, error) {
+    talents, err := GetAllTalents(ctx)
+    if err != nil {
+        return nil, err
+    }
+    if ctx.Patch != nil {
+        talents = ctx.Patch.OverlayTalents(talents, func(t *Talent) bool { return true })
+    }
+    return talents, nil
+}
+
+// updateWindowTitle shows the dry-run state and the number of pending statements in the title
+func updateWindowTitle(ctx *AppContext) {
+    switch {
+    case ctx.ReadOnly:
+        ctx.Window.SetTitle(fmt.Sprintf("%s [%s]", windowTitle, tr("READ ONLY")))
+    case ctx.Patch != nil:
+        ctx.Window.SetTitle(fmt.Sprintf("%s [%s]", windowTitle, trf("DRY RUN: %d pending", len(ctx.Patch.Statements))))
+    default:
+        ctx.Window.SetTitle(windowTitle)
+    }
 }
 
 func resetEditorContainer(ctx *AppContext) {
     ctx.EditorContainer.Objects = nil
-    ctx.EditorContainer.Add(widget.NewLabel("Select a talent cell to edit"))
+    ctx.EditorContainer.Add(widget.NewLabel(tr("Select a talent cell to edit")))
     ctx.EditorContainer.Refresh()
 }
\ No newline at end of file
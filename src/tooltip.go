@@ -0,0 +1,89 @@
+// Copyright (c) 2025 TalentEditor
+//
+// TalentEditor is licensed under the MIT License.
+// See the LICENSE file for details.
+
+package main
+
+import (
+    "strconv"
+    "strings"
+
+    "fyne.io/fyne/v2"
+    "fyne.io/fyne/v2/container"
+    "fyne.io/fyne/v2/widget"
+)
+
+// TooltipOptions selects the rank shown in the in-game style talent tooltips
+type TooltipOptions struct {
+    Rank     int  // previewed rank, 0 for an unlearned talent, clamped to the talent's rank count
+    NextRank bool // also show the description of the next rank
+}
+
+// talentTooltip builds the in-game tooltip of a talent: name, "Rank x/N", the tier and
+// prerequisite requirements and the description of the current and next rank
+func talentTooltip(t *Talent, tabTitle string, talents map[int]*Talent, spells map[int]Spell, describe func(Spell) string, opts TooltipOptions) string {
+    ranks := talentRankCount(t)
+    rank := min(max(opts.Rank, 0), ranks)
+
+    lines := []string{talentDisplayName(t, spells), trf("Rank %d/%d", rank, ranks)}
+
+    if tier := int(t.TierID.Int64); tier > 0 {
+        lines = append(lines, trn(tier*5, "Requires %d point in %s Talents", "Requires %d points in %s Talents", tabTitle))
+    }
+    for i, p := range t.PreReqTalent {
+        if !p.Valid || p.Int64 == 0 {
+            continue
+        }
+        name := trf("Talent %d", p.Int64)
+        if req, ok := talents[int(p.Int64)]; ok {
+            name = talentDisplayName(req, spells)
+        }
+        lines = append(lines, trn(int(t.PreReqRank[i].Int64)+1, "Requires %d point in %s", "Requires %d points in %s", name))
+    }
+
+    rankText := func(r int) string {
+        if r < 1 || r > len(t.Rank) || !t.Rank[r-1].Valid {
+            return ""
+        }
+        spell, ok := spells[int(t.Rank[r-1].Int64)]
+        if !ok {
+            return trf("(spell %d not found)", t.Rank[r-1].Int64)
+        }
+        return describe(spell)
+    }
+
+    if rank > 0 {
+        lines = append(lines, "", rankText(rank))
+    }
+    // An unlearned talent always shows the first rank, like in game
+    if rank < ranks && (rank == 0 || opts.NextRank) {
+        lines = append(lines, "", tr("Next rank:"), rankText(rank+1))
+    }
+
+    lines = append(lines, "", trf("Talent ID %d", t.ID))
+    return strings.Join(lines, "\n")
+}
+
+// newTooltipControls builds the preview rank and next rank controls shown above the grid
+func newTooltipControls(ctx *AppContext, reloadTab func()) fyne.CanvasObject {
+    options := make([]string, 10)
+    for i := range options {
+        options[i] = strconv.Itoa(i)
+    }
+    rankSelect := widget.NewSelect(options, nil)
+    rankSelect.SetSelected(strconv.Itoa(ctx.Tooltip.Rank))
+    rankSelect.OnChanged = func(s string) {
+        ctx.Tooltip.Rank, _ = strconv.Atoi(s)
+        reloadTab()
+    }
+
+    nextRank := widget.NewCheck(tr("Show next rank"), nil)
+    nextRank.SetChecked(ctx.Tooltip.NextRank)
+    nextRank.OnChanged = func(checked bool) {
+        ctx.Tooltip.NextRank = checked
+        reloadTab()
+    }
+
+    return container.NewHBox(widget.NewLabel(tr("Tooltip rank")), rankSelect, nextRank)
+}
@@ -0,0 +1,67 @@
+// Copyright (c) 2025 TalentEditor
+//
+// TalentEditor is licensed under the MIT License.
+// See the LICENSE file for details.
+
+package main
+
+import (
+    "database/sql"
+    "fmt"
+    "strings"
+    "testing"
+)
+
+func TestTalentTooltipRanks(t *testing.T) {
+    talent := &Talent{ID: 7, TierID: sql.NullInt64{Int64: 1, Valid: true}}
+    spells := make(map[int]Spell)
+    for i := 0; i < 3; i++ {
+        id := 1000 + i
+        talent.Rank[i] = sql.NullInt64{Int64: int64(id), Valid: true}
+        spells[id] = Spell{ID: id, NameENUS: "Improved Fireball", Desc: fmt.Sprintf("rank %d text", i+1)}
+    }
+    // The tab loads the spells of all ranks, a tooltip of any rank must find its spell
+    if ids := allRankSpellIDs([]Talent{*talent}); len(ids) != 3 {
+        t.Fatalf("allRankSpellIDs = %v, want the 3 rank spells", ids)
+    }
+    describe := func(s Spell) string { return s.Desc }
+
+    tests := []struct {
+        opts TooltipOptions
+        want []string
+    }{
+        {TooltipOptions{Rank: 0}, []string{"Rank 0/3", "Next rank:", "rank 1 text"}},
+        {TooltipOptions{Rank: 2}, []string{"Rank 2/3", "rank 2 text"}},
+        {TooltipOptions{Rank: 2, NextRank: true}, []string{"rank 2 text", "Next rank:", "rank 3 text"}},
+        {TooltipOptions{Rank: 9, NextRank: true}, []string{"Rank 3/3", "rank 3 text"}},
+    }
+    for _, tt := range tests {
+        got := talentTooltip(talent, "Fire", nil, spells, describe, tt.opts)
+        if strings.Contains(got, "not found") {
+            t.Errorf("%+v: tooltip misses a rank spell:\n%s", tt.opts, got)
+        }
+        for _, w := range tt.want {
+            if !strings.Contains(got, w) {
+                t.Errorf("%+v: tooltip lacks %q:\n%s", tt.opts, w, got)
+            }
+        }
+    }
+}
+
+func TestTalentTooltipGerman(t *testing.T) {
+    defer func(l string) { uiLanguage = l }(uiLanguage)
+    uiLanguage = "de"
+
+    talent := &Talent{ID: 7, TierID: sql.NullInt64{Int64: 1, Valid: true}}
+    talent.Rank[0] = sql.NullInt64{Int64: 1000, Valid: true}
+    talent.PreReqTalent[0] = sql.NullInt64{Int64: 8, Valid: true}
+    spells := map[int]Spell{1000: {ID: 1000, NameENUS: "Improved Fireball", Desc: "text"}}
+    describe := func(s Spell) string { return s.Desc }
+
+    got := talentTooltip(talent, "Feuer", nil, spells, describe, TooltipOptions{})
+    for _, w := range []string{"Rang 0/1", "Benötigt 5 Punkte in Feuer-Talenten", "Benötigt 1 Punkt in Talent 8", "Nächster Rang:", "Talent-ID 7"} {
+        if !strings.Contains(got, w) {
+            t.Errorf("tooltip lacks %q:\n%s", w, got)
+        }
+    }
+}